@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	_ "unsafe"
+)
+
+// -----------------------------------------------------------------------------
+// Context Snapshot
+//
+// The trace context and baggage live in the goroutine, and a new goroutine
+// starts with a snapshot of its creator's. Tasks handed over to long-lived
+// workers, e.g. the goroutines of a pool, run on goroutines created long before
+// the task is submitted, so they would see whatever the worker ran last.
+// CaptureContext takes a snapshot of the submitter's context and Run restores
+// it around the task, rules of such libraries wrap the submitted tasks with
+// WrapTask or WrapErrTask.
+
+//go:linkname otel_get_trace_context_from_gls otel_get_trace_context_from_gls
+var otel_get_trace_context_from_gls func() interface{}
+
+//go:linkname otel_set_trace_context_to_gls otel_set_trace_context_to_gls
+var otel_set_trace_context_to_gls func(interface{})
+
+//go:linkname otel_get_baggage_container_from_gls otel_get_baggage_container_from_gls
+var otel_get_baggage_container_from_gls func() interface{}
+
+//go:linkname otel_set_baggage_container_to_gls otel_set_baggage_container_to_gls
+var otel_set_baggage_container_to_gls func(interface{})
+
+var (
+	getTraceContext = func() interface{} { return nil }
+	setTraceContext = func(interface{}) {}
+	getBaggage      = func() interface{} { return nil }
+	setBaggage      = func(interface{}) {}
+)
+
+func init() {
+	if otel_get_trace_context_from_gls != nil && otel_set_trace_context_to_gls != nil {
+		getTraceContext = otel_get_trace_context_from_gls
+		setTraceContext = otel_set_trace_context_to_gls
+	}
+	if otel_get_baggage_container_from_gls != nil && otel_set_baggage_container_to_gls != nil {
+		getBaggage = otel_get_baggage_container_from_gls
+		setBaggage = otel_set_baggage_container_to_gls
+	}
+}
+
+type contextSnapshoter interface {
+	TakeSnapShot() interface{}
+}
+
+// snapshotOf copies the goroutine local value so that the goroutines sharing a
+// snapshot do not modify the same value.
+func snapshotOf(v interface{}) interface{} {
+	if taker, ok := v.(contextSnapshoter); ok {
+		return taker.TakeSnapShot()
+	}
+	return v
+}
+
+// ContextSnapshot is the trace context and baggage of a goroutine at the time
+// it is captured.
+type ContextSnapshot struct {
+	traceContext interface{}
+	baggage      interface{}
+}
+
+// CaptureContext takes a snapshot of the context of the current goroutine, it
+// returns nil if there is nothing to propagate.
+func CaptureContext() *ContextSnapshot {
+	traceContext, baggage := getTraceContext(), getBaggage()
+	if traceContext == nil && baggage == nil {
+		return nil
+	}
+	return &ContextSnapshot{traceContext: snapshotOf(traceContext), baggage: snapshotOf(baggage)}
+}
+
+// Run executes task with the captured context as the context of the current
+// goroutine and restores the goroutine's own context afterwards. A snapshot
+// can be run any number of times, also concurrently.
+func (s *ContextSnapshot) Run(task func()) {
+	if s == nil {
+		task()
+		return
+	}
+	prevTraceContext, prevBaggage := getTraceContext(), getBaggage()
+	setTraceContext(snapshotOf(s.traceContext))
+	setBaggage(snapshotOf(s.baggage))
+	defer func() {
+		setTraceContext(prevTraceContext)
+		setBaggage(prevBaggage)
+	}()
+	task()
+}
+
+// RunDetached executes task with an empty context as the context of the
+// current goroutine and restores the goroutine's own context afterwards. Spans
+// started by task neither inherit nor stay in the context of the goroutine,
+// e.g. spans that are ended on another goroutine.
+func RunDetached(task func()) {
+	(&ContextSnapshot{}).Run(task)
+}
+
+// WrapTask binds task to the context of the calling goroutine, wherever the
+// returned function is executed.
+func WrapTask(task func()) func() {
+	s := CaptureContext()
+	if s == nil || task == nil {
+		return task
+	}
+	return func() {
+		s.Run(task)
+	}
+}
+
+// WrapErrTask is WrapTask for tasks that return an error.
+func WrapErrTask(task func() error) func() error {
+	s := CaptureContext()
+	if s == nil || task == nil {
+		return task
+	}
+	return func() error {
+		var err error
+		s.Run(func() {
+			err = task()
+		})
+		return err
+	}
+}
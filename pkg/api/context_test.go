@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTraceContext struct {
+	span string
+}
+
+func (tc *testTraceContext) TakeSnapShot() interface{} {
+	return &testTraceContext{span: tc.span}
+}
+
+// useTestGLS replaces the goroutine local storage with a single slot, the
+// tests only run the tasks on the current goroutine.
+func useTestGLS(t *testing.T) *interface{} {
+	var slot interface{}
+	oldGet, oldSet := getTraceContext, setTraceContext
+	getTraceContext = func() interface{} { return slot }
+	setTraceContext = func(v interface{}) { slot = v }
+	t.Cleanup(func() {
+		getTraceContext, setTraceContext = oldGet, oldSet
+	})
+	return &slot
+}
+
+func currentSpan(slot *interface{}) string {
+	if tc, ok := (*slot).(*testTraceContext); ok {
+		return tc.span
+	}
+	return ""
+}
+
+func TestCaptureContextWithoutContext(t *testing.T) {
+	useTestGLS(t)
+	assert.Nil(t, CaptureContext())
+	ran := false
+	CaptureContext().Run(func() { ran = true })
+	assert.True(t, ran)
+}
+
+func TestContextSnapshotRun(t *testing.T) {
+	slot := useTestGLS(t)
+	*slot = &testTraceContext{span: "submitter"}
+	s := CaptureContext()
+	*slot = &testTraceContext{span: "worker"}
+
+	var seen string
+	s.Run(func() {
+		seen = currentSpan(slot)
+		// the task must not change the snapshot shared with other tasks
+		(*slot).(*testTraceContext).span = "task"
+	})
+	assert.Equal(t, "submitter", seen)
+	assert.Equal(t, "worker", currentSpan(slot))
+
+	s.Run(func() { seen = currentSpan(slot) })
+	assert.Equal(t, "submitter", seen)
+}
+
+func TestContextSnapshotRunRestoresOnPanic(t *testing.T) {
+	slot := useTestGLS(t)
+	*slot = &testTraceContext{span: "submitter"}
+	s := CaptureContext()
+	*slot = nil
+	assert.Panics(t, func() {
+		s.Run(func() { panic("boom") })
+	})
+	assert.Nil(t, *slot)
+}
+
+func TestRunDetached(t *testing.T) {
+	slot := useTestGLS(t)
+	*slot = &testTraceContext{span: "worker"}
+	seen := "unset"
+	RunDetached(func() {
+		seen = currentSpan(slot)
+		*slot = &testTraceContext{span: "task"}
+	})
+	assert.Equal(t, "", seen)
+	assert.Equal(t, "worker", currentSpan(slot))
+}
+
+func TestWrapTask(t *testing.T) {
+	slot := useTestGLS(t)
+	assert.Nil(t, WrapTask(nil))
+
+	*slot = &testTraceContext{span: "submitter"}
+	var seen string
+	task := WrapTask(func() { seen = currentSpan(slot) })
+	*slot = nil
+	task()
+	assert.Equal(t, "submitter", seen)
+	assert.Nil(t, *slot)
+}
+
+func TestWrapErrTask(t *testing.T) {
+	slot := useTestGLS(t)
+	*slot = &testTraceContext{span: "submitter"}
+	var seen string
+	errBoom := errors.New("boom")
+	task := WrapErrTask(func() error {
+		seen = currentSpan(slot)
+		return errBoom
+	})
+	*slot = nil
+	assert.Equal(t, errBoom, task())
+	assert.Equal(t, "submitter", seen)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const envTracesSampler = "OTEL_TRACES_SAMPLER"
+
+var samplingRatio = config.Float("sampler", "ratio", 1, "Ratio of new traces to sample, between 0 and 1.", "OTEL_TRACES_SAMPLER_ARG")
+
+// ratioSampler samples by trace ID ratio read from the config on every
+// decision, so that a reloaded ratio applies to new traces immediately.
+type ratioSampler struct {
+	current atomic.Pointer[ratioState]
+}
+
+type ratioState struct {
+	ratio   float64
+	sampler sdktrace.Sampler
+}
+
+func (s *ratioSampler) delegate() sdktrace.Sampler {
+	ratio := samplingRatio.Get()
+	if state := s.current.Load(); state != nil && state.ratio == ratio {
+		return state.sampler
+	}
+	state := &ratioState{ratio: ratio, sampler: sdktrace.TraceIDRatioBased(ratio)}
+	s.current.Store(state)
+	return state.sampler
+}
+
+func (s *ratioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return s.delegate().ShouldSample(p)
+}
+
+func (s *ratioSampler) Description() string {
+	return fmt.Sprintf("DynamicTraceIDRatioBased{%g}", samplingRatio.Get())
+}
+
+// NewRatioSampler returns a trace ID ratio sampler whose ratio is the
+// otel.instrumentation.sampler.ratio setting.
+func NewRatioSampler() sdktrace.Sampler {
+	return &ratioSampler{}
+}
+
+// FromEnv returns the reloadable sampler matching OTEL_TRACES_SAMPLER, or nil
+// if the configured sampler is not ratio based and is left to the SDK.
+func FromEnv() sdktrace.Sampler {
+	switch os.Getenv(envTracesSampler) {
+	case "", "parentbased_traceidratio":
+		return sdktrace.ParentBased(NewRatioSampler())
+	case "traceidratio":
+		return NewRatioSampler()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRatioSamplerFollowsConfig(t *testing.T) {
+	s := NewRatioSampler()
+	params := sdktrace.SamplingParameters{
+		TraceID: trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	if s.ShouldSample(params).Decision != sdktrace.RecordAndSample {
+		t.Error("expected the trace to be sampled with the default ratio")
+	}
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_SAMPLER_RATIO", "0")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if s.ShouldSample(params).Decision != sdktrace.Drop {
+		t.Error("expected the trace to be dropped after the ratio is set to 0")
+	}
+	if s.Description() != "DynamicTraceIDRatioBased{0}" {
+		t.Errorf("unexpected description %s", s.Description())
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(envTracesSampler, "always_on")
+	if FromEnv() != nil {
+		t.Error("non ratio based sampler should be left to the SDK")
+	}
+	t.Setenv(envTracesSampler, "traceidratio")
+	if _, ok := FromEnv().(*ratioSampler); !ok {
+		t.Error("expected the reloadable ratio sampler")
+	}
+}
@@ -17,10 +17,9 @@ package db
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 
-	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
@@ -30,24 +29,9 @@ import (
 
 const EnvDBExperimentalEnabled = "OTEL_INSTRUMENTATION_DB_EXPERIMENTAL_ENABLE"
 
-type dbExperimentalEnabler struct {
-	enabled bool
-}
-
-func (d dbExperimentalEnabler) Enable() bool {
-	return d.enabled
-}
-
-var experimentalAttributesEnabler instrumenter.InstrumentEnabler = &dbExperimentalEnabler{
-	// SQL params parsing is not enabled by default.
-	enabled: func() bool {
-		val, err := strconv.ParseBool(os.Getenv(EnvDBExperimentalEnabled))
-		if err != nil {
-			return false
-		}
-		return val
-	}(),
-}
+// SQL params parsing is not enabled by default.
+var experimentalAttributesEnabled = config.Bool("db", "experimental.enable", false,
+	"Enable the capture of experimental database span attributes.")
 
 type DbClientCommonAttrsExtractor[REQUEST any, RESPONSE any, GETTER DbClientCommonAttrsGetter[REQUEST]] struct {
 	Getter           GETTER
@@ -89,7 +73,7 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 	attrs, context = d.Base.OnEnd(attrs, context, request, response, err)
 	attrs = append(attrs, attribute.KeyValue{
 		Key:   semconv.DBQueryTextKey,
-		Value: attribute.StringValue(d.Base.Getter.GetStatement(request)),
+		Value: attribute.StringValue(d.statement(request)),
 	}, attribute.KeyValue{
 		Key:   semconv.DBOperationNameKey,
 		Value: attribute.StringValue(d.Base.Getter.GetOperation(request)),
@@ -108,10 +92,20 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 	if dbNameSpace != "" {
 		attrs = append(attrs, attribute.KeyValue{Key: semconv.DBNamespaceKey, Value: attribute.StringValue(dbNameSpace)})
 	}
+	if rowsGetter, ok := any(d.Base.Getter).(DbClientReturnedRowsGetter[REQUEST, RESPONSE]); ok {
+		if rows := rowsGetter.GetReturnedRows(request, response); rows >= 0 {
+			attrs = append(attrs, attribute.KeyValue{Key: semconv.DBResponseReturnedRowsKey, Value: attribute.Int64Value(rows)})
+		}
+	}
+	if codeGetter, ok := any(d.Base.Getter).(DbClientResponseStatusCodeGetter[REQUEST, RESPONSE]); ok {
+		if code := codeGetter.GetResponseStatusCode(request, response, err); code != "" {
+			attrs = append(attrs, attribute.KeyValue{Key: semconv.DBResponseStatusCodeKey, Value: attribute.StringValue(code)})
+		}
+	}
 	if d.Base.AttributesFilter != nil {
 		attrs = d.Base.AttributesFilter(attrs)
 	}
-	if experimentalAttributesEnabler.Enable() {
+	if experimentalAttributesEnabled.Get() {
 		params := d.Base.Getter.GetParameters(request)
 		if len(params) > 0 {
 			for i, param := range params {
@@ -122,9 +116,17 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 	return attrs, context
 }
 
+func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) statement(request REQUEST) string {
+	if StatementSanitizerEnabled() {
+		if getter, ok := any(d.Base.Getter).(DbClientSanitizedStatementGetter[REQUEST]); ok {
+			return getter.GetSanitizedStatement(request)
+		}
+	}
+	return d.Base.Getter.GetStatement(request)
+}
+
 func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) GetSpanKey() attribute.Key {
 	return utils.DB_CLIENT_KEY
 }
 
-// TODO: sanitize sql
 // TODO: batch sql
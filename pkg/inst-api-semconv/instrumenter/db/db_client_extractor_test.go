@@ -16,6 +16,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"log"
 	"testing"
 
@@ -134,3 +135,68 @@ func TestDbClientExtractorWithFilter(t *testing.T) {
 		panic("attribute should be test")
 	}
 }
+
+type rowsAttrsGetter struct {
+	mongoAttrsGetter
+}
+
+func (r rowsAttrsGetter) GetReturnedRows(request testRequest, response int64) int64 {
+	return response
+}
+
+func TestDbClientExtractorReturnedRows(t *testing.T) {
+	dbExtractor := DbClientAttrsExtractor[testRequest, int64, rowsAttrsGetter]{}
+	attrs, _ := dbExtractor.OnEnd(nil, context.Background(), testRequest{}, 3, nil)
+	found := false
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBResponseReturnedRowsKey {
+			found = true
+			if attr.Value.AsInt64() != 3 {
+				t.Fatalf("expected 3 returned rows, got %d", attr.Value.AsInt64())
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected db.response.returned_rows to be recorded")
+	}
+	attrs, _ = dbExtractor.OnEnd(nil, context.Background(), testRequest{}, -1, nil)
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBResponseReturnedRowsKey {
+			t.Fatal("unknown row count should not be recorded")
+		}
+	}
+}
+
+type statusCodeAttrsGetter struct {
+	mongoAttrsGetter
+}
+
+func (s statusCodeAttrsGetter) GetResponseStatusCode(request testRequest, response testResponse, err error) string {
+	if err == nil {
+		return ""
+	}
+	return "11000"
+}
+
+func TestDbClientExtractorResponseStatusCode(t *testing.T) {
+	dbExtractor := DbClientAttrsExtractor[testRequest, testResponse, statusCodeAttrsGetter]{}
+	attrs, _ := dbExtractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, errors.New("duplicate key"))
+	found := false
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBResponseStatusCodeKey {
+			found = true
+			if attr.Value.AsString() != "11000" {
+				t.Fatalf("expected status code 11000, got %s", attr.Value.AsString())
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected db.response.status_code to be recorded")
+	}
+	attrs, _ = dbExtractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBResponseStatusCodeKey {
+			t.Fatal("unknown status code should not be recorded")
+		}
+	}
+}
@@ -33,3 +33,24 @@ type SqlClientAttributesGetter[REQUEST any] interface {
 	DbClientCommonAttrsGetter[REQUEST]
 	GetRawStatement(REQUEST) string
 }
+
+// DbClientSanitizedStatementGetter is optionally implemented by getters of
+// SQL-like systems. When the statement sanitizer is enabled, its result is
+// recorded as db.query.text instead of GetStatement.
+type DbClientSanitizedStatementGetter[REQUEST any] interface {
+	GetSanitizedStatement(REQUEST) string
+}
+
+// DbClientReturnedRowsGetter is optionally implemented by getters that know
+// how many rows a query returned, which is recorded as
+// db.response.returned_rows. A negative count means unknown.
+type DbClientReturnedRowsGetter[REQUEST any, RESPONSE any] interface {
+	GetReturnedRows(request REQUEST, response RESPONSE) int64
+}
+
+// DbClientResponseStatusCodeGetter is optionally implemented by getters that
+// know the status code the database returned for a failed operation, which is
+// recorded as db.response.status_code. An empty code means unknown.
+type DbClientResponseStatusCodeGetter[REQUEST any, RESPONSE any] interface {
+	GetResponseStatusCode(request REQUEST, response RESPONSE, err error) string
+}
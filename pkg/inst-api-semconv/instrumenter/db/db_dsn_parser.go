@@ -0,0 +1,354 @@
+// Copyright (c) 2024 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	nurl "net/url"
+	"strings"
+	"sync"
+)
+
+// DSNInfo is what a DSN parser extracts from a data source name.
+type DSNInfo struct {
+	Host     string
+	Port     string
+	User     string
+	Database string
+}
+
+// Addr returns host:port, or an empty string for embedded databases.
+func (i DSNInfo) Addr() string {
+	if i.Host == "" {
+		return ""
+	}
+	if i.Port == "" {
+		return i.Host
+	}
+	return net.JoinHostPort(i.Host, i.Port)
+}
+
+type dsnParser struct {
+	system      string
+	defaultPort string
+	parse       func(dsn string) (DSNInfo, error)
+}
+
+var (
+	dsnParsers = map[string]dsnParser{}
+	// dsnInfos caches parsed DSNs by driver and DSN, every statement needs
+	// the database name of its connection.
+	dsnInfos sync.Map
+)
+
+// registerDSNParser registers the parser of the data source names accepted
+// by the given sql drivers, as well as the db.system.name they report.
+func registerDSNParser(system, defaultPort string, parse func(dsn string) (DSNInfo, error), drivers ...string) {
+	for _, driver := range drivers {
+		dsnParsers[driver] = dsnParser{system: system, defaultPort: defaultPort, parse: parse}
+	}
+}
+
+func init() {
+	registerDSNParser("mysql", "3306", parseMySQL, "mysql")
+	// TiDB speaks the MySQL protocol and takes MySQL DSNs
+	registerDSNParser("tidb", "4000", parseMySQL, "tidb")
+	registerDSNParser("postgresql", "5432", parsePostgres, "postgres", "postgresql", "pgx", "pgx/v5", "cloudsqlpostgres")
+	registerDSNParser("sqlite", "", parseSQLite, "sqlite3", "sqlite")
+	registerDSNParser("microsoft.sql_server", "1433", parseSQLServer, "sqlserver", "mssql", "azuresql")
+	registerDSNParser("clickhouse", "9000", parseClickHouse, "clickhouse")
+	registerDSNParser("oracle.db", "1521", parseOracle, "godror", "oracle")
+	registerDSNParser("snowflake", "443", parseSnowflake, "snowflake")
+}
+
+// DbSystemOf returns the db.system.name reported by the given sql driver.
+func DbSystemOf(driverName string) string {
+	if p, ok := dsnParsers[driverName]; ok {
+		return p.system
+	}
+	return "database"
+}
+
+// IsKnownDriver reports whether the DSNs of the given sql driver can be parsed.
+func IsKnownDriver(driverName string) bool {
+	_, ok := dsnParsers[driverName]
+	return ok
+}
+
+// ParseDSN parses the data source name accepted by the given sql driver.
+func ParseDSN(driverName, dsn string) (DSNInfo, error) {
+	p, ok := dsnParsers[driverName]
+	if !ok {
+		return DSNInfo{}, fmt.Errorf("unsupported driver %q", driverName)
+	}
+	info, err := p.parse(dsn)
+	if err != nil {
+		return DSNInfo{}, err
+	}
+	if info.Host != "" && info.Port == "" {
+		info.Port = p.defaultPort
+	}
+	return info, nil
+}
+
+// LookupDSN returns the parsed DSN from the cache, unparsable DSNs are cached
+// as empty infos.
+func LookupDSN(driverName, dsn string) DSNInfo {
+	key := driverName + "\x00" + dsn
+	if info, ok := dsnInfos.Load(key); ok {
+		return info.(DSNInfo)
+	}
+	info, _ := ParseDSN(driverName, dsn)
+	dsnInfos.Store(key, info)
+	return info
+}
+
+func splitHostPort(hostport string) (string, string) {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return strings.Trim(hostport, "[]"), ""
+	}
+	return host, port
+}
+
+// parseMySQL parses [user[:password]@][net[(addr)]]/dbname[?params].
+func parseMySQL(dsn string) (DSNInfo, error) {
+	var info DSNInfo
+	slash := strings.LastIndexByte(dsn, '/')
+	if slash < 0 {
+		return info, errors.New("invalid MySQL DSN")
+	}
+	info.Database, _, _ = strings.Cut(dsn[slash+1:], "?")
+	rest := dsn[:slash]
+	if at := strings.LastIndexByte(rest, '@'); at >= 0 {
+		info.User, _, _ = strings.Cut(rest[:at], ":")
+		rest = rest[at+1:]
+	}
+	if i, j := strings.IndexByte(rest, '('), strings.LastIndexByte(rest, ')'); i >= 0 && j > i {
+		if rest[:i] == "unix" {
+			return info, nil
+		}
+		info.Host, info.Port = splitHostPort(rest[i+1 : j])
+	} else if rest == "" || rest == "tcp" {
+		info.Host = "127.0.0.1"
+	}
+	return info, nil
+}
+
+// parsePostgres parses both postgres://user@host:port/dbname URLs and
+// keyword/value strings like "host=localhost port=5432 dbname=test".
+func parsePostgres(dsn string) (DSNInfo, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := nurl.Parse(dsn)
+		if err != nil {
+			return DSNInfo{}, err
+		}
+		info := urlInfo(u)
+		info.Database = strings.TrimPrefix(u.Path, "/")
+		if info.Host == "" {
+			info.Host = u.Query().Get("host")
+		}
+		return info, nil
+	}
+	if !strings.Contains(dsn, "=") {
+		return DSNInfo{}, errors.New("invalid PostgreSQL DSN")
+	}
+	kv := parseKeyValues(dsn, " ")
+	info := DSNInfo{Host: kv["host"], Port: kv["port"], User: kv["user"], Database: kv["dbname"]}
+	// Multiple hosts may be given, the first one is reported.
+	info.Host, _, _ = strings.Cut(info.Host, ",")
+	info.Port, _, _ = strings.Cut(info.Port, ",")
+	if info.Host == "" {
+		info.Host = "localhost"
+	}
+	return info, nil
+}
+
+// parseSQLite reports the database file as the namespace, embedded databases
+// have no server address.
+func parseSQLite(dsn string) (DSNInfo, error) {
+	path := strings.TrimPrefix(dsn, "file:")
+	path, _, _ = strings.Cut(path, "?")
+	if path == "" {
+		return DSNInfo{}, errors.New("invalid SQLite DSN")
+	}
+	return DSNInfo{Database: path}, nil
+}
+
+// parseSQLServer parses sqlserver://user@host:port/instance?database=db URLs
+// and ADO strings like "server=host;user id=sa;database=db".
+func parseSQLServer(dsn string) (DSNInfo, error) {
+	if strings.HasPrefix(dsn, "sqlserver://") {
+		u, err := nurl.Parse(dsn)
+		if err != nil {
+			return DSNInfo{}, err
+		}
+		info := urlInfo(u)
+		info.Database = u.Query().Get("database")
+		return info, nil
+	}
+	if strings.HasPrefix(dsn, "odbc:") {
+		dsn = dsn[len("odbc:"):]
+	}
+	if !strings.Contains(dsn, "=") {
+		return DSNInfo{}, errors.New("invalid SQL Server DSN")
+	}
+	kv := parseKeyValues(dsn, ";")
+	info := DSNInfo{Port: kv["port"], User: kv["user id"], Database: kv["database"]}
+	server := kv["server"]
+	if server == "" {
+		server = kv["data source"]
+	}
+	// server may be host\instance, host,port or tcp:host,port.
+	server = strings.TrimPrefix(server, "tcp:")
+	server, _, _ = strings.Cut(server, `\`)
+	if host, port, ok := strings.Cut(server, ","); ok {
+		server, info.Port = host, port
+	}
+	info.Host = server
+	if info.User == "" {
+		info.User = kv["user"]
+	}
+	if info.Database == "" {
+		info.Database = kv["initial catalog"]
+	}
+	return info, nil
+}
+
+// parseClickHouse parses clickhouse://user@host:port/db and
+// tcp://host:port?database=db URLs.
+func parseClickHouse(dsn string) (DSNInfo, error) {
+	u, err := nurl.Parse(dsn)
+	if err != nil {
+		return DSNInfo{}, err
+	}
+	if u.Host == "" {
+		return DSNInfo{}, errors.New("invalid ClickHouse DSN")
+	}
+	info := urlInfo(u)
+	// Multiple hosts may be given, the first one is reported.
+	host, _, _ := strings.Cut(u.Host, ",")
+	info.Host, info.Port = splitHostPort(host)
+	info.Database = strings.TrimPrefix(u.Path, "/")
+	query := u.Query()
+	if info.Database == "" {
+		info.Database = query.Get("database")
+	}
+	if info.User == "" {
+		info.User = query.Get("username")
+	}
+	return info, nil
+}
+
+// parseOracle parses oracle://user@host:port/service URLs, godror logfmt
+// strings with a connectString and user/password@host:port/service.
+func parseOracle(dsn string) (DSNInfo, error) {
+	if strings.HasPrefix(dsn, "oracle://") {
+		u, err := nurl.Parse(dsn)
+		if err != nil {
+			return DSNInfo{}, err
+		}
+		info := urlInfo(u)
+		info.Database = strings.TrimPrefix(u.Path, "/")
+		return info, nil
+	}
+	var info DSNInfo
+	connect := dsn
+	if strings.Contains(dsn, "connectString=") {
+		kv := parseKeyValues(dsn, " ")
+		info.User, connect = kv["user"], kv["connectString"]
+	} else if at := strings.LastIndexByte(dsn, '@'); at >= 0 {
+		info.User, _, _ = strings.Cut(dsn[:at], "/")
+		connect = dsn[at+1:]
+	}
+	// Easy Connect: [//]host[:port][/service_name][?params]
+	connect = strings.TrimPrefix(connect, "//")
+	connect, _, _ = strings.Cut(connect, "?")
+	if connect == "" || strings.HasPrefix(connect, "(") {
+		// TNS descriptors and aliases are resolved by the client.
+		return info, nil
+	}
+	hostport, service, _ := strings.Cut(connect, "/")
+	info.Host, info.Port = splitHostPort(hostport)
+	info.Database, _, _ = strings.Cut(service, ":")
+	return info, nil
+}
+
+// parseSnowflake parses user[:password]@account[/database[/schema]][?params]
+// and user[:password]@host:port/database/schema?account=account.
+func parseSnowflake(dsn string) (DSNInfo, error) {
+	var info DSNInfo
+	at := strings.LastIndexByte(dsn, '@')
+	if at < 0 {
+		return info, errors.New("invalid Snowflake DSN")
+	}
+	info.User, _, _ = strings.Cut(dsn[:at], ":")
+	rest, _, _ := strings.Cut(dsn[at+1:], "?")
+	hostport, path, _ := strings.Cut(rest, "/")
+	info.Database, _, _ = strings.Cut(path, "/")
+	info.Host, info.Port = splitHostPort(hostport)
+	if info.Port == "" && !strings.HasSuffix(info.Host, ".snowflakecomputing.com") {
+		info.Host += ".snowflakecomputing.com"
+	}
+	return info, nil
+}
+
+func urlInfo(u *nurl.URL) DSNInfo {
+	info := DSNInfo{Host: u.Hostname(), Port: u.Port()}
+	if u.User != nil {
+		info.User = u.User.Username()
+	}
+	return info
+}
+
+// parseKeyValues parses key=value pairs separated by sep, values may be
+// quoted. Keys are lower-cased except for connectString.
+func parseKeyValues(dsn, sep string) map[string]string {
+	kv := map[string]string{}
+	for _, field := range splitOutsideQuotes(dsn, sep) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key != "connectString" {
+			key = strings.ToLower(key)
+		}
+		kv[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return kv
+}
+
+func splitOutsideQuotes(s, sep string) []string {
+	var fields []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case strings.HasPrefix(s[i:], sep):
+			fields = append(fields, s[start:i])
+			start = i + len(sep)
+		}
+	}
+	return append(fields, s[start:])
+}
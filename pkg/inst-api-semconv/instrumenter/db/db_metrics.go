@@ -49,8 +49,9 @@ var globalMeter metric.Meter
 // related to issue Dbs://github.com/alibaba/loongsuite-go-agent/issues/48
 func InitDbMetrics(m metric.Meter) {
 	mu.Lock()
-	defer mu.Unlock()
 	globalMeter = m
+	mu.Unlock()
+	initPoolInstruments()
 }
 
 func DbClientMetrics(key string) *DbClientMetric {
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// MongoRequest is a command sent by a MongoDB driver, as reported to the
+// command monitor of the client. It is shared by the rules of every major
+// version of the driver.
+type MongoRequest struct {
+	CommandName  string
+	Host         string
+	DatabaseName string
+	Collection   string
+	BatchSize    int
+	StatusCode   string
+}
+
+type mongoRequestAttrsGetter struct {
+}
+
+func (m mongoRequestAttrsGetter) GetSystem(request MongoRequest) string {
+	return "mongodb"
+}
+
+func (m mongoRequestAttrsGetter) GetServerAddress(request MongoRequest) string {
+	return request.Host
+}
+
+func (m mongoRequestAttrsGetter) GetStatement(request MongoRequest) string {
+	return request.CommandName
+}
+
+func (m mongoRequestAttrsGetter) GetCollection(request MongoRequest) string {
+	return request.Collection
+}
+
+func (m mongoRequestAttrsGetter) GetOperation(request MongoRequest) string {
+	return request.CommandName
+}
+
+func (m mongoRequestAttrsGetter) GetParameters(request MongoRequest) []any {
+	return nil
+}
+
+func (m mongoRequestAttrsGetter) GetBatchSize(request MongoRequest) int {
+	return request.BatchSize
+}
+
+func (m mongoRequestAttrsGetter) GetDbNamespace(request MongoRequest) string {
+	return request.DatabaseName
+}
+
+func (m mongoRequestAttrsGetter) GetResponseStatusCode(request MongoRequest, response any, err error) string {
+	return request.StatusCode
+}
+
+type mongoSpanNameExtractor struct {
+}
+
+func (m *mongoSpanNameExtractor) Extract(request MongoRequest) string {
+	return request.CommandName
+}
+
+// BuildMongoInstrumenter builds the instrumenter of MongoDB commands, scopeName
+// tells the rule of the driver apart.
+func BuildMongoInstrumenter(scopeName string) instrumenter.Instrumenter[MongoRequest, any] {
+	builder := instrumenter.Builder[MongoRequest, any]{}
+	return builder.Init().SetSpanNameExtractor(&mongoSpanNameExtractor{}).
+		AddOperationListeners(DbClientMetrics("nosql.mongo")).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[MongoRequest]{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    scopeName,
+			Version: version.Tag,
+		}).
+		AddAttributesExtractor(&DbClientAttrsExtractor[MongoRequest, any, mongoRequestAttrsGetter]{Base: DbClientCommonAttrsExtractor[MongoRequest, any, mongoRequestAttrsGetter]{Getter: mongoRequestAttrsGetter{}}}).
+		BuildInstrumenter()
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestMongoRequestAttrs(t *testing.T) {
+	dbExtractor := DbClientAttrsExtractor[MongoRequest, any, mongoRequestAttrsGetter]{
+		Base: DbClientCommonAttrsExtractor[MongoRequest, any, mongoRequestAttrsGetter]{Getter: mongoRequestAttrsGetter{}},
+	}
+	request := MongoRequest{
+		CommandName:  "insert",
+		Host:         "127.0.0.1:27017",
+		DatabaseName: "shop",
+		Collection:   "users",
+		BatchSize:    2,
+		StatusCode:   "11000",
+	}
+	startAttrs, _ := dbExtractor.OnStart(nil, context.Background(), request)
+	attrs, _ := dbExtractor.OnEnd(startAttrs, context.Background(), request, nil, errors.New("duplicate key"))
+	expected := map[attribute.Key]attribute.Value{
+		semconv.DBSystemNameKey:         attribute.StringValue("mongodb"),
+		semconv.DBNamespaceKey:          attribute.StringValue("shop"),
+		semconv.DBCollectionNameKey:     attribute.StringValue("users"),
+		semconv.DBOperationNameKey:      attribute.StringValue("insert"),
+		semconv.DBOperationBatchSizeKey: attribute.IntValue(2),
+		semconv.DBResponseStatusCodeKey: attribute.StringValue("11000"),
+		semconv.ServerAddressKey:        attribute.StringValue("127.0.0.1:27017"),
+	}
+	for key, value := range expected {
+		found := false
+		for _, attr := range attrs {
+			if attr.Key == key {
+				found = true
+				if attr.Value != value {
+					t.Errorf("%s = %v, want %v", key, attr.Value.Emit(), value.Emit())
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s is not recorded in %v", key, attrs)
+		}
+	}
+}
+
+func TestMongoSpanName(t *testing.T) {
+	extractor := &mongoSpanNameExtractor{}
+	if name := extractor.Extract(MongoRequest{CommandName: "find"}); name != "find" {
+		t.Errorf("Extract() = %q, want find", name)
+	}
+}
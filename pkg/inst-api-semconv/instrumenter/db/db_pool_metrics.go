@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const (
+	db_client_connection_count                = "db.client.connection.count"
+	db_client_connection_max                  = "db.client.connection.max"
+	db_client_connection_wait_count           = "db.client.connection.wait.count"
+	db_client_connection_wait_duration        = "db.client.connection.wait.duration"
+	db_client_connection_closed_max_idle      = "db.client.connection.closed.max_idle"
+	db_client_connection_closed_max_idle_time = "db.client.connection.closed.max_idle_time"
+	db_client_connection_closed_max_lifetime  = "db.client.connection.closed.max_lifetime"
+	dbClientConnectionDriverKey               = attribute.Key("db.driver.name")
+)
+
+// DbClientPoolStats is a snapshot of a client side connection pool, it
+// mirrors sql.DBStats so that other pools can report the same metrics.
+type DbClientPoolStats struct {
+	MaxOpenConnections int64
+	InUse              int64
+	Idle               int64
+	WaitCount          int64
+	WaitDuration       time.Duration
+	MaxIdleClosed      int64
+	MaxIdleTimeClosed  int64
+	MaxLifetimeClosed  int64
+}
+
+type dbClientPool struct {
+	driver   string
+	endpoint string
+	stats    func() DbClientPoolStats
+}
+
+type dbClientPoolKey struct {
+	driver   string
+	endpoint string
+}
+
+type dbClientPoolInstruments struct {
+	count             metric.Int64ObservableUpDownCounter
+	max               metric.Int64ObservableUpDownCounter
+	waitCount         metric.Int64ObservableCounter
+	waitDuration      metric.Float64ObservableCounter
+	maxIdleClosed     metric.Int64ObservableCounter
+	maxIdleTimeClosed metric.Int64ObservableCounter
+	maxLifetimeClosed metric.Int64ObservableCounter
+}
+
+var (
+	poolMu      sync.Mutex
+	pools       = map[*dbClientPool]struct{}{}
+	poolMetrics *dbClientPoolInstruments
+	// retiredPools keeps the final counters of the unregistered pools so that
+	// the cumulative metrics of a driver and endpoint never go down.
+	retiredPools = map[dbClientPoolKey]DbClientPoolStats{}
+)
+
+// RegisterDbClientPool reports the db.client.connection.* metrics of a
+// connection pool until the returned function is called. Pools sharing the
+// same driver and endpoint are summed up, the counters keep the values of the
+// unregistered pools.
+func RegisterDbClientPool(driver, endpoint string, stats func() DbClientPoolStats) (unregister func()) {
+	p := &dbClientPool{driver: driver, endpoint: endpoint, stats: stats}
+	poolMu.Lock()
+	pools[p] = struct{}{}
+	poolMu.Unlock()
+	initPoolInstruments()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// DB.Stats() takes the pool lock, so it is called outside of poolMu.
+			s := p.stats()
+			key := dbClientPoolKey{driver: p.driver, endpoint: p.endpoint}
+			poolMu.Lock()
+			defer poolMu.Unlock()
+			delete(pools, p)
+			if s.WaitCount == 0 && s.WaitDuration == 0 && s.MaxIdleClosed == 0 &&
+				s.MaxIdleTimeClosed == 0 && s.MaxLifetimeClosed == 0 {
+				return
+			}
+			retired := retiredPools[key]
+			addPoolCounters(&retired, s)
+			retiredPools[key] = retired
+		})
+	}
+}
+
+// initPoolInstruments creates the connection pool instruments once the meter
+// is set, it is retried by every registration and by InitDbMetrics.
+func initPoolInstruments() {
+	mu.Lock()
+	meter := globalMeter
+	mu.Unlock()
+	if meter == nil {
+		return
+	}
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if poolMetrics != nil {
+		return
+	}
+	var err error
+	poolMetrics, err = newDbClientPoolInstruments(meter)
+	if err != nil {
+		log.Printf("failed to create db client connection metrics, %v\n", err)
+	}
+}
+
+func addPoolCounters(total *DbClientPoolStats, s DbClientPoolStats) {
+	total.WaitCount += s.WaitCount
+	total.WaitDuration += s.WaitDuration
+	total.MaxIdleClosed += s.MaxIdleClosed
+	total.MaxIdleTimeClosed += s.MaxIdleTimeClosed
+	total.MaxLifetimeClosed += s.MaxLifetimeClosed
+}
+
+func newDbClientPoolInstruments(meter metric.Meter) (*dbClientPoolInstruments, error) {
+	var err error
+	m := &dbClientPoolInstruments{}
+	if m.count, err = meter.Int64ObservableUpDownCounter(db_client_connection_count,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently in state described by the state attribute.")); err != nil {
+		return nil, err
+	}
+	if m.max, err = meter.Int64ObservableUpDownCounter(db_client_connection_max,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The maximum number of open connections allowed.")); err != nil {
+		return nil, err
+	}
+	if m.waitCount, err = meter.Int64ObservableCounter(db_client_connection_wait_count,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("The total number of connections waited for.")); err != nil {
+		return nil, err
+	}
+	if m.waitDuration, err = meter.Float64ObservableCounter(db_client_connection_wait_duration,
+		metric.WithUnit("s"),
+		metric.WithDescription("The total time blocked waiting for a new connection.")); err != nil {
+		return nil, err
+	}
+	if m.maxIdleClosed, err = meter.Int64ObservableCounter(db_client_connection_closed_max_idle,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The total number of connections closed due to the maximum idle connections.")); err != nil {
+		return nil, err
+	}
+	if m.maxIdleTimeClosed, err = meter.Int64ObservableCounter(db_client_connection_closed_max_idle_time,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The total number of connections closed due to the maximum idle time.")); err != nil {
+		return nil, err
+	}
+	if m.maxLifetimeClosed, err = meter.Int64ObservableCounter(db_client_connection_closed_max_lifetime,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The total number of connections closed due to the maximum connection lifetime.")); err != nil {
+		return nil, err
+	}
+	if _, err = meter.RegisterCallback(m.observe, m.count, m.max, m.waitCount, m.waitDuration,
+		m.maxIdleClosed, m.maxIdleTimeClosed, m.maxLifetimeClosed); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *dbClientPoolInstruments) observe(_ context.Context, observer metric.Observer) error {
+	poolMu.Lock()
+	snapshot := make([]*dbClientPool, 0, len(pools))
+	for p := range pools {
+		snapshot = append(snapshot, p)
+	}
+	totals := make(map[dbClientPoolKey]DbClientPoolStats, len(snapshot)+len(retiredPools))
+	for key, s := range retiredPools {
+		totals[key] = s
+	}
+	poolMu.Unlock()
+
+	// DB.Stats() takes the pool lock, so it is called outside of poolMu.
+	for _, p := range snapshot {
+		key := dbClientPoolKey{driver: p.driver, endpoint: p.endpoint}
+		s, total := p.stats(), totals[key]
+		total.MaxOpenConnections += s.MaxOpenConnections
+		total.InUse += s.InUse
+		total.Idle += s.Idle
+		addPoolCounters(&total, s)
+		totals[key] = total
+	}
+	for key, s := range totals {
+		attrs := []attribute.KeyValue{
+			semconv.DBClientConnectionPoolName(key.endpoint),
+			dbClientConnectionDriverKey.String(key.driver),
+		}
+		set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+		observer.ObserveInt64(m.count, s.Idle, metric.WithAttributeSet(attribute.NewSet(append(attrs, semconv.DBClientConnectionStateIdle)...)))
+		observer.ObserveInt64(m.count, s.InUse, metric.WithAttributeSet(attribute.NewSet(append(attrs, semconv.DBClientConnectionStateUsed)...)))
+		observer.ObserveInt64(m.max, s.MaxOpenConnections, set)
+		observer.ObserveInt64(m.waitCount, s.WaitCount, set)
+		observer.ObserveFloat64(m.waitDuration, s.WaitDuration.Seconds(), set)
+		observer.ObserveInt64(m.maxIdleClosed, s.MaxIdleClosed, set)
+		observer.ObserveInt64(m.maxIdleTimeClosed, s.MaxIdleTimeClosed, set)
+		observer.ObserveInt64(m.maxLifetimeClosed, s.MaxLifetimeClosed, set)
+	}
+	return nil
+}
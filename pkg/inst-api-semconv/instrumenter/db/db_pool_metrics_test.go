@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestDbClientPoolMetrics(t *testing.T) {
+	resetPoolMetrics()
+	t.Cleanup(resetPoolMetrics)
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitDbMetrics(mp.Meter("test-meter"))
+
+	stats := DbClientPoolStats{
+		MaxOpenConnections: 10,
+		InUse:              3,
+		Idle:               2,
+		WaitCount:          4,
+		WaitDuration:       1500 * time.Millisecond,
+		MaxLifetimeClosed:  1,
+	}
+	unregister1 := RegisterDbClientPool("mysql", "127.0.0.1:3306", func() DbClientPoolStats { return stats })
+	unregister2 := RegisterDbClientPool("mysql", "127.0.0.1:3306", func() DbClientPoolStats { return stats })
+	defer unregister1()
+
+	metrics := collectPoolMetrics(t, reader)
+	used := attribute.NewSet(semconv.DBClientConnectionPoolName("127.0.0.1:3306"),
+		dbClientConnectionDriverKey.String("mysql"), semconv.DBClientConnectionStateUsed)
+	if got := int64Point(t, metrics[db_client_connection_count], used); got != 6 {
+		t.Errorf("expected 6 used connections, got %d", got)
+	}
+	pool := attribute.NewSet(semconv.DBClientConnectionPoolName("127.0.0.1:3306"),
+		dbClientConnectionDriverKey.String("mysql"))
+	if got := int64Point(t, metrics[db_client_connection_max], pool); got != 20 {
+		t.Errorf("expected max 20 connections, got %d", got)
+	}
+	if got := int64Point(t, metrics[db_client_connection_closed_max_lifetime], pool); got != 2 {
+		t.Errorf("expected 2 connections closed by lifetime, got %d", got)
+	}
+	waitDuration := metrics[db_client_connection_wait_duration].Data.(metricdata.Sum[float64])
+	if len(waitDuration.DataPoints) != 1 || waitDuration.DataPoints[0].Value != 3 {
+		t.Errorf("unexpected wait duration %v", waitDuration.DataPoints)
+	}
+
+	unregister2()
+	metrics = collectPoolMetrics(t, reader)
+	if got := int64Point(t, metrics[db_client_connection_count], used); got != 3 {
+		t.Errorf("expected 3 used connections after unregister, got %d", got)
+	}
+	if got := int64Point(t, metrics[db_client_connection_closed_max_lifetime], pool); got != 2 {
+		t.Errorf("expected closed connections to stay at 2 after unregister, got %d", got)
+	}
+	unregister2()
+	metrics = collectPoolMetrics(t, reader)
+	if got := int64Point(t, metrics[db_client_connection_closed_max_lifetime], pool); got != 2 {
+		t.Errorf("expected unregister to be idempotent, got %d closed connections", got)
+	}
+}
+
+func TestDbClientPoolMetricsLateMeter(t *testing.T) {
+	resetPoolMetrics()
+	t.Cleanup(resetPoolMetrics)
+	unregister := RegisterDbClientPool("postgres", "127.0.0.1:5432", func() DbClientPoolStats {
+		return DbClientPoolStats{MaxOpenConnections: 5, Idle: 1}
+	})
+	defer unregister()
+
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitDbMetrics(mp.Meter("test-meter"))
+	metrics := collectPoolMetrics(t, reader)
+	pool := attribute.NewSet(semconv.DBClientConnectionPoolName("127.0.0.1:5432"),
+		dbClientConnectionDriverKey.String("postgres"))
+	if got := int64Point(t, metrics[db_client_connection_max], pool); got != 5 {
+		t.Errorf("expected max 5 connections, got %d", got)
+	}
+}
+
+func resetPoolMetrics() {
+	InitDbMetrics(nil)
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	poolMetrics = nil
+	pools = map[*dbClientPool]struct{}{}
+	retiredPools = map[dbClientPoolKey]DbClientPoolStats{}
+}
+
+func collectPoolMetrics(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func int64Point(t *testing.T, m metricdata.Metrics, set attribute.Set) int64 {
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("metric %s is not an int64 sum", m.Name)
+	}
+	for _, dp := range sum.DataPoints {
+		if dp.Attributes.Equals(&set) {
+			return dp.Value
+		}
+	}
+	t.Fatalf("no data point of %s with attributes %v", m.Name, set.ToSlice())
+	return 0
+}
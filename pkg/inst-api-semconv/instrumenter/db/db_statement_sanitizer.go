@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+)
+
+const sanitizedPlaceholder = "?"
+
+var statementSanitizerEnabled = config.Bool("db", "statement-sanitizer.enabled", true,
+	"Replace literals in db.query.text with '?' and collapse IN lists.",
+	"OTEL_INSTRUMENTATION_COMMON_DB_STATEMENT_SANITIZER_ENABLED")
+
+var inListPattern = regexp.MustCompile(`(?i)\b(in)\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+// ddlKeywords lead the statements which carry type parameters and options,
+// e.g. VARCHAR(255), whose numbers are kept as written until the DML body of
+// the statement, e.g. the query of CREATE TABLE ... AS SELECT, starts.
+var ddlKeywords = map[string]bool{
+	"ALTER":    true,
+	"CREATE":   true,
+	"DROP":     true,
+	"RENAME":   true,
+	"TRUNCATE": true,
+}
+
+// dmlBodyKeywords start the parts of DDL statements that carry data, like
+// queries of views and tables and predicates of partial indexes.
+var dmlBodyKeywords = map[string]bool{
+	"SELECT": true,
+	"VALUES": true,
+	"WHERE":  true,
+}
+
+// ansiQuotesSystems are the databases quoting identifiers with double quotes,
+// the others, e.g. MySQL without ANSI_QUOTES, quote strings with them.
+var ansiQuotesSystems = map[string]bool{
+	"postgresql":           true,
+	"sqlite":               true,
+	"microsoft.sql_server": true,
+	"oracle.db":            true,
+	"clickhouse":           true,
+	"snowflake":            true,
+	"cassandra":            true,
+}
+
+// StatementSanitizerEnabled reports whether SQL statements should be
+// sanitized before being recorded as db.query.text.
+func StatementSanitizerEnabled() bool {
+	return statementSanitizerEnabled.Get()
+}
+
+// SanitizeSql replaces every literal of a SQL statement with '?' and
+// collapses IN lists of literals to a single 'IN (?)'. Only the literals are
+// replaced, keywords, identifiers, comments and whitespace are kept as
+// written. The system is the db.system.name or the sql driver name of the
+// database, which tells whether double quotes enclose identifiers or strings.
+func SanitizeSql(system, statement string) string {
+	if statement == "" {
+		return statement
+	}
+	ddl := ddlKeywords[strings.ToUpper(firstWord(statement))]
+	sanitized := sanitizeByLexer(statement, usesAnsiQuotes(system), ddl)
+	return inListPattern.ReplaceAllString(sanitized, "$1 (?)")
+}
+
+func usesAnsiQuotes(system string) bool {
+	return ansiQuotesSystems[system] || ansiQuotesSystems[DbSystemOf(system)]
+}
+
+// firstWord returns the leading keyword of the statement, skipping blanks
+// and comments before it.
+func firstWord(statement string) string {
+	n := len(statement)
+	i := 0
+	for i < n {
+		switch {
+		case statement[i] == ' ' || statement[i] == '\t' || statement[i] == '\n' || statement[i] == '\r' || statement[i] == '(':
+			i++
+		case strings.HasPrefix(statement[i:], "--"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				return ""
+			}
+			i += end
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				return ""
+			}
+			i += 2 + end + 2
+		default:
+			end := i
+			for end < n && isIdentChar(statement[end]) {
+				end++
+			}
+			return statement[i:end]
+		}
+	}
+	return ""
+}
+
+// sanitizeByLexer masks quoted strings, dollar-quoted strings and numeric
+// literals while keeping identifiers, comments and bind markers intact. With
+// ansiQuotes double-quoted text is an identifier, and in DDL statements the
+// numbers are kept until a DML body keyword is met.
+func sanitizeByLexer(statement string, ansiQuotes, ddl bool) string {
+	var sb strings.Builder
+	sb.Grow(len(statement))
+	n := len(statement)
+	for i := 0; i < n; {
+		c := statement[i]
+		switch {
+		case c == '\'' || (c == '"' && !ansiQuotes):
+			i = skipQuoted(statement, i, c)
+			sb.WriteString(sanitizedPlaceholder)
+		case c == '"' || c == '`':
+			end := skipQuoted(statement, i, c)
+			sb.WriteString(statement[i:end])
+			i = end
+		case c == '-' && i+1 < n && statement[i+1] == '-':
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = n - i
+			}
+			sb.WriteString(statement[i : i+end])
+			i += end
+		case c == '/' && i+1 < n && statement[i+1] == '*':
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = n
+			} else {
+				end = i + 2 + end + 2
+			}
+			sb.WriteString(statement[i:end])
+			i = end
+		case c == '$':
+			if end, ok := skipDollarQuoted(statement, i); ok {
+				sb.WriteString(sanitizedPlaceholder)
+				i = end
+				break
+			}
+			// Positional parameters such as $1 are kept as they are.
+			end := i + 1
+			for end < n && isDigit(statement[end]) {
+				end++
+			}
+			sb.WriteString(statement[i:end])
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < n && isDigit(statement[i+1])):
+			end := skipNumber(statement, i)
+			if ddl {
+				sb.WriteString(statement[i:end])
+			} else {
+				sb.WriteString(sanitizedPlaceholder)
+			}
+			i = end
+		case isIdentChar(c):
+			end := i
+			for end < n && (isIdentChar(statement[end]) || isDigit(statement[end])) {
+				end++
+			}
+			if ddl && dmlBodyKeywords[strings.ToUpper(statement[i:end])] {
+				ddl = false
+			}
+			sb.WriteString(statement[i:end])
+			i = end
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+func skipQuoted(s string, start int, quote byte) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// skipDollarQuoted skips a PostgreSQL dollar-quoted string like $$..$$ or
+// $tag$..$tag$ starting at start.
+func skipDollarQuoted(s string, start int) (int, bool) {
+	end := start + 1
+	for end < len(s) && isIdentChar(s[end]) {
+		end++
+	}
+	if end >= len(s) || s[end] != '$' {
+		return 0, false
+	}
+	tag := s[start : end+1]
+	closing := strings.Index(s[end+1:], tag)
+	if closing < 0 {
+		return len(s), true
+	}
+	return end + 1 + closing + len(tag), true
+}
+
+func skipNumber(s string, start int) int {
+	i := start
+	if s[i] == '0' && i+1 < len(s) && (s[i+1] == 'x' || s[i+1] == 'X') {
+		i += 2
+		for i < len(s) && isHexDigit(s[i]) {
+			i++
+		}
+		return i
+	}
+	for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+		i++
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			i = j
+			for i < len(s) && isDigit(s[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestSanitizeSql(t *testing.T) {
+	cases := []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"select string", "SELECT * FROM users WHERE name = 'alice'", "SELECT * FROM users WHERE name = ?"},
+		{"select number", "select id from t where id = 42 and score > 1.5e3", "select id from t where id = ? and score > ?"},
+		{"in list", "SELECT * FROM t WHERE id IN (1, 2, 3)", "SELECT * FROM t WHERE id IN (?)"},
+		{"not in list", "SELECT * FROM t WHERE name NOT IN ('a', 'b')", "SELECT * FROM t WHERE name NOT IN (?)"},
+		{"in columns kept", "SELECT * FROM t WHERE a IN (b, 1)", "SELECT * FROM t WHERE a IN (b, ?)"},
+		{"insert", "INSERT INTO t (a, b) VALUES ('x', 2)", "INSERT INTO t (a, b) VALUES (?, ?)"},
+		{"update", "UPDATE t SET password = 'secret' WHERE id = 7", "UPDATE t SET password = ? WHERE id = ?"},
+		{"delete", "DELETE FROM t WHERE token = 'abc'", "DELETE FROM t WHERE token = ?"},
+		{"bind markers", "SELECT * FROM t WHERE id = ?", "SELECT * FROM t WHERE id = ?"},
+		{"named parameters", "UPDATE users SET name = :name WHERE id = :id", "UPDATE users SET name = :name WHERE id = :id"},
+		{"limit", "SELECT * FROM t LIMIT 10", "SELECT * FROM t LIMIT ?"},
+		{"postgres", "SELECT * FROM t WHERE id = $1 AND name = 'bob' AND n IN (1,2 , 3)", "SELECT * FROM t WHERE id = $1 AND name = ? AND n IN (?)"},
+		{"escaped quote", "SELECT 'it''s' FROM t WHERE x = 'a\\'b' RETURNING id", "SELECT ? FROM t WHERE x = ? RETURNING id"},
+		{"dollar quoted", "SELECT $tag$secret$tag$, $$x$$ FROM t RETURNING id", "SELECT ?, ? FROM t RETURNING id"},
+		{"comments kept", "SELECT 1 /* x=1 */ -- y=2\nFROM t RETURNING id", "SELECT ? /* x=1 */ -- y=2\nFROM t RETURNING id"},
+		{"cql", "INSERT INTO ks.users (id, name) VALUES (5, 'bob') USING TTL 60", "INSERT INTO ks.users (id, name) VALUES (?, ?) USING TTL ?"},
+		{"formatting kept", "insert into t\n  (a, b)\nvalues ( 'x', 2 );", "insert into t\n  (a, b)\nvalues ( ?, ? );"},
+		{"function call kept", "SELECT VERSION()", "SELECT VERSION()"},
+		{"ddl type parameters kept", "CREATE TABLE t (id INT, name VARCHAR(20) DEFAULT 'x')", "CREATE TABLE t (id INT, name VARCHAR(20) DEFAULT ?)"},
+		{"ddl after comment kept", "/* migrate */ ALTER TABLE t ADD c CHAR(2)", "/* migrate */ ALTER TABLE t ADD c CHAR(2)"},
+		{"create table as select", "CREATE TABLE t2 AS SELECT * FROM users WHERE ssn = '123-45-6789' AND age > 30", "CREATE TABLE t2 AS SELECT * FROM users WHERE ssn = ? AND age > ?"},
+		{"create view", "CREATE VIEW v (c) AS SELECT id FROM t WHERE n IN (1, 2)", "CREATE VIEW v (c) AS SELECT id FROM t WHERE n IN (?)"},
+		{"partial index", "CREATE INDEX i ON t (c) WHERE c > 5", "CREATE INDEX i ON t (c) WHERE c > ?"},
+		{"ddl with values", "CREATE TABLE t2 (a CHAR(3)) AS VALUES (123)", "CREATE TABLE t2 (a CHAR(3)) AS VALUES (?)"},
+		{"double quoted string", `SELECT * FROM users WHERE password = "hunter2" AND n = "a\"b"`, `SELECT * FROM users WHERE password = ? AND n = ?`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := SanitizeSql("mysql", c.sql); got != c.expected {
+				t.Errorf("SanitizeSql(%q) = %q, want %q", c.sql, got, c.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeSqlQuotes(t *testing.T) {
+	cases := []struct {
+		system   string
+		sql      string
+		expected string
+	}{
+		{"mysql", `SELECT "col1" FROM t WHERE a = "x"`, `SELECT ? FROM t WHERE a = ?`},
+		{"", `SELECT * FROM t WHERE a = "x"`, `SELECT * FROM t WHERE a = ?`},
+		{"postgresql", `SELECT "col1", t2.c3 FROM "t1" WHERE c = 0x1F RETURNING id`, `SELECT "col1", t2.c3 FROM "t1" WHERE c = ? RETURNING id`},
+		{"pgx", `SELECT "col1" FROM "t1" WHERE c = 'x'`, `SELECT "col1" FROM "t1" WHERE c = ?`},
+		{"sqlite3", `CREATE TABLE "t" AS SELECT "a" FROM "s" WHERE b = 'y'`, `CREATE TABLE "t" AS SELECT "a" FROM "s" WHERE b = ?`},
+		{"cassandra", `SELECT "Name" FROM ks.t WHERE id = 1`, `SELECT "Name" FROM ks.t WHERE id = ?`},
+		{"mysql", "SELECT `col1` FROM t WHERE a = 'x'", "SELECT `col1` FROM t WHERE a = ?"},
+	}
+	for _, c := range cases {
+		if got := SanitizeSql(c.system, c.sql); got != c.expected {
+			t.Errorf("SanitizeSql(%q, %q) = %q, want %q", c.system, c.sql, got, c.expected)
+		}
+	}
+}
+
+type sanitizingAttrsGetter struct {
+	mongoAttrsGetter
+}
+
+func (s sanitizingAttrsGetter) GetStatement(request testRequest) string {
+	return "SELECT * FROM t WHERE id = 1"
+}
+
+func (s sanitizingAttrsGetter) GetSanitizedStatement(request testRequest) string {
+	return SanitizeSql("mysql", s.GetStatement(request))
+}
+
+func TestDbClientExtractorSanitizesStatement(t *testing.T) {
+	dbExtractor := DbClientAttrsExtractor[testRequest, testResponse, sanitizingAttrsGetter]{}
+	attrs, _ := dbExtractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	if got := queryText(attrs); got != "SELECT * FROM t WHERE id = ?" {
+		t.Fatalf("unexpected db.query.text %q", got)
+	}
+
+	// Registered before Setenv so that it runs after the variable is restored.
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_DB_STATEMENT_SANITIZER_ENABLED", "false")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	attrs, _ = dbExtractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	if got := queryText(attrs); got != "SELECT * FROM t WHERE id = 1" {
+		t.Fatalf("unexpected db.query.text %q", got)
+	}
+}
+
+func queryText(attrs []attribute.KeyValue) string {
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBQueryTextKey {
+			return attr.Value.AsString()
+		}
+	}
+	return ""
+}
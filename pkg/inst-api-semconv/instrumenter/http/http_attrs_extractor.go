@@ -59,6 +59,14 @@ func (h *HttpCommonAttrsExtractor[REQUEST, RESPONSE, GETTER, GETTER2]) OnEnd(att
 	if errorType != "" {
 		attributes = append(attributes, attribute.KeyValue{Key: semconv.ErrorTypeKey, Value: attribute.StringValue(errorType)})
 	}
+	if sizeGetter, ok := any(h.HttpGetter).(HttpBodySizeGetter[REQUEST, RESPONSE]); ok {
+		if size := sizeGetter.GetHttpRequestBodySize(request, response); size >= 0 {
+			attributes = append(attributes, attribute.KeyValue{Key: semconv.HTTPRequestBodySizeKey, Value: attribute.Int64Value(size)})
+		}
+		if size := sizeGetter.GetHttpResponseBodySize(request, response); size >= 0 {
+			attributes = append(attributes, attribute.KeyValue{Key: semconv.HTTPResponseBodySizeKey, Value: attribute.Int64Value(size)})
+		}
+	}
 	return attributes, context
 }
 
@@ -82,6 +90,9 @@ func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnStart(
 		Key:   semconv.ServerPortKey,
 		Value: attribute.IntValue(h.Base.HttpGetter.GetServerPort(request)),
 	})
+	attributes = appendCapturedHeaders(attributes, requestHeaderPrefix, clientRequestHeaders.Get(), func(name string) []string {
+		return h.Base.HttpGetter.GetHttpRequestHeader(request, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -91,6 +102,9 @@ func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnStart(
 func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnEnd(attributes []attribute.KeyValue, context context.Context, request REQUEST, response RESPONSE, err error) ([]attribute.KeyValue, context.Context) {
 	attributes, context = h.Base.OnEnd(attributes, context, request, response, err)
 	attributes, context = h.NetworkExtractor.OnEnd(attributes, context, request, response, err)
+	attributes = appendCapturedHeaders(attributes, responseHeaderPrefix, clientResponseHeaders.Get(), func(name string) []string {
+		return h.Base.HttpGetter.GetHttpResponseHeader(request, response, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -121,6 +135,9 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 		Key:   semconv.UserAgentOriginalKey,
 		Value: attribute.StringValue(firstUserAgent),
 	})
+	attributes = appendCapturedHeaders(attributes, requestHeaderPrefix, serverRequestHeaders.Get(), func(name string) []string {
+		return h.Base.HttpGetter.GetHttpRequestHeader(request, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -143,6 +160,9 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 			Value: attribute.StringValue(route),
 		})
 	}
+	attributes = appendCapturedHeaders(attributes, responseHeaderPrefix, serverResponseHeaders.Get(), func(name string) []string {
+		return h.Base.HttpGetter.GetHttpResponseHeader(request, response, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -17,6 +17,7 @@ package http
 import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/net"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 	"go.opentelemetry.io/otel/trace"
@@ -443,3 +444,68 @@ func TestNonRecordingSpan(t *testing.T) {
 		t.Fatalf("wrong network peer port")
 	}
 }
+
+func TestHttpServerExtractorCaptureHeaders(t *testing.T) {
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SERVER_REQUEST", "X-Request-Id,Authorization")
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SERVER_RESPONSE", "Content-Type")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	httpServerExtractor := HttpServerAttrsExtractor[testRequest, testResponse, httpServerAttrsGetter, networkAttrsGetter, urlAttrsGetter]{
+		Base:             HttpCommonAttrsExtractor[testRequest, testResponse, httpServerAttrsGetter, networkAttrsGetter]{},
+		NetworkExtractor: net.NetworkAttrsExtractor[testRequest, testResponse, networkAttrsGetter]{},
+		UrlExtractor:     net.UrlAttrsExtractor[testRequest, testResponse, urlAttrsGetter]{},
+	}
+	attrs := make([]attribute.KeyValue, 0)
+	attrs, _ = httpServerExtractor.OnStart(attrs, context.Background(), testRequest{})
+	attrs, _ = httpServerExtractor.OnEnd(attrs, context.Background(), testRequest{}, testResponse{}, nil)
+	expected := map[attribute.Key]string{
+		"http.request.header.x-request-id":  "request-header",
+		"http.request.header.authorization": "REDACTED",
+		"http.response.header.content-type": "response-header",
+	}
+	found := 0
+	for _, attr := range attrs {
+		if v, ok := expected[attr.Key]; ok {
+			found++
+			if s := attr.Value.AsStringSlice(); len(s) != 1 || s[0] != v {
+				t.Errorf("%s = %v; expected [%s]", attr.Key, s, v)
+			}
+		}
+	}
+	if found != len(expected) {
+		t.Errorf("expected %d header attributes, got %d", len(expected), found)
+	}
+}
+
+func TestHttpClientExtractorCaptureHeaders(t *testing.T) {
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_CLIENT_REQUEST", "X-Request-Id")
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_CLIENT_RESPONSE", "Set-Cookie")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	httpClientExtractor := HttpClientAttrsExtractor[testRequest, testResponse, httpClientAttrsGetter, networkAttrsGetter]{
+		Base:             HttpCommonAttrsExtractor[testRequest, testResponse, httpClientAttrsGetter, networkAttrsGetter]{},
+		NetworkExtractor: net.NetworkAttrsExtractor[testRequest, testResponse, networkAttrsGetter]{},
+	}
+	attrs := make([]attribute.KeyValue, 0)
+	attrs, _ = httpClientExtractor.OnStart(attrs, context.Background(), testRequest{})
+	attrs, _ = httpClientExtractor.OnEnd(attrs, context.Background(), testRequest{}, testResponse{}, nil)
+	var request, response []string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "http.request.header.x-request-id":
+			request = attr.Value.AsStringSlice()
+		case "http.response.header.set-cookie":
+			response = attr.Value.AsStringSlice()
+		}
+	}
+	if len(request) != 1 || request[0] != "request-header" {
+		t.Errorf("unexpected request header %v", request)
+	}
+	if len(response) != 1 || response[0] != "REDACTED" {
+		t.Errorf("unexpected response header %v", response)
+	}
+}
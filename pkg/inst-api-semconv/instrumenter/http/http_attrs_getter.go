@@ -41,3 +41,12 @@ type HttpClientAttrsGetter[REQUEST any, RESPONSE any] interface {
 	GetServerAddress(request REQUEST) string
 	GetServerPort(request REQUEST) int
 }
+
+// HttpBodySizeGetter is optionally implemented by the attrs getters of the
+// instrumentations that know the body sizes, which are recorded as
+// http.request.body.size and http.response.body.size. A negative size means
+// unknown.
+type HttpBodySizeGetter[REQUEST any, RESPONSE any] interface {
+	GetHttpRequestBodySize(request REQUEST, response RESPONSE) int64
+	GetHttpResponseBodySize(request REQUEST, response RESPONSE) int64
+}
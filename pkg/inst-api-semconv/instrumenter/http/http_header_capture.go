@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	requestHeaderPrefix  = "http.request.header."
+	responseHeaderPrefix = "http.response.header."
+	redactedHeaderValue  = "REDACTED"
+)
+
+var (
+	serverRequestHeaders  = config.StringSlice("http", "capture-headers.server.request", nil, "HTTP request headers captured as span attributes by server instrumentations.")
+	serverResponseHeaders = config.StringSlice("http", "capture-headers.server.response", nil, "HTTP response headers captured as span attributes by server instrumentations.")
+	clientRequestHeaders  = config.StringSlice("http", "capture-headers.client.request", nil, "HTTP request headers captured as span attributes by client instrumentations.")
+	clientResponseHeaders = config.StringSlice("http", "capture-headers.client.response", nil, "HTTP response headers captured as span attributes by client instrumentations.")
+	sensitiveHeaders      = config.StringSlice("http", "capture-headers.sensitive", []string{
+		"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token",
+	}, "Captured HTTP headers whose values are replaced with REDACTED.")
+)
+
+// appendCapturedHeaders appends the http.request.header.<name> or
+// http.response.header.<name> attributes of the configured headers. Values of
+// sensitive headers are redacted.
+func appendCapturedHeaders(attributes []attribute.KeyValue, prefix string, names []string, getter func(name string) []string) []attribute.KeyValue {
+	for _, name := range names {
+		values := getter(name)
+		if len(values) == 0 {
+			continue
+		}
+		attributes = append(attributes, attribute.StringSlice(prefix+strings.ToLower(name), RedactSensitiveHeader(name, values)))
+	}
+	return attributes
+}
+
+// RedactSensitiveHeader returns the values of a captured header, replaced with
+// REDACTED if the header is one of the otel.instrumentation.http.capture-headers.sensitive
+// headers. Messaging instrumentations redact the headers they capture with it.
+func RedactSensitiveHeader(name string, values []string) []string {
+	if !isSensitiveHeader(name) {
+		return values
+	}
+	redacted := make([]string, len(values))
+	for i := range redacted {
+		redacted[i] = redactedHeaderValue
+	}
+	return redacted
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaders.Get() {
+		if strings.EqualFold(sensitive, name) {
+			return true
+		}
+	}
+	return false
+}
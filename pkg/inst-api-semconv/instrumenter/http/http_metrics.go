@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/utils"
+	instutils "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
@@ -31,14 +32,34 @@ const http_server_request_duration = "http.server.request.duration"
 
 const http_client_request_duration = "http.client.request.duration"
 
+const http_server_request_body_size = "http.server.request.body.size"
+
+const http_server_response_body_size = "http.server.response.body.size"
+
+const http_server_active_requests = "http.server.active_requests"
+
+const http_client_request_body_size = "http.client.request.body.size"
+
+const http_client_response_body_size = "http.client.response.body.size"
+
+const http_client_active_requests = "http.client.active_requests"
+
 type HttpServerMetric struct {
-	key                   attribute.Key
-	serverRequestDuration metric.Float64Histogram
+	key                    attribute.Key
+	serverRequestDuration  metric.Float64Histogram
+	serverRequestBodySize  metric.Int64Histogram
+	serverResponseBodySize metric.Int64Histogram
+	serverActiveRequests   metric.Int64UpDownCounter
+	instruments            instutils.InstrumentsOnce
 }
 
 type HttpClientMetric struct {
-	key                   attribute.Key
-	clientRequestDuration metric.Float64Histogram
+	key                    attribute.Key
+	clientRequestDuration  metric.Float64Histogram
+	clientRequestBodySize  metric.Int64Histogram
+	clientResponseBodySize metric.Int64Histogram
+	clientActiveRequests   metric.Int64UpDownCounter
+	instruments            instutils.InstrumentsOnce
 }
 
 var mu sync.Mutex
@@ -55,8 +76,24 @@ var httpMetricsConv = map[attribute.Key]bool{
 	semconv.ServerPortKey:             true,
 }
 
+// httpActiveRequestsConv lists the attributes of the active requests metrics,
+// which are known when the request starts.
+var httpActiveRequestsConv = map[attribute.Key]bool{
+	semconv.HTTPRequestMethodKey: true,
+	semconv.URLSchemeKey:         true,
+	semconv.ServerAddressKey:     true,
+	semconv.ServerPortKey:        true,
+}
+
 var globalMeter metric.Meter
 
+// currentMeter returns the meter set by the otel setup, nil before it ran.
+func currentMeter() metric.Meter {
+	mu.Lock()
+	defer mu.Unlock()
+	return globalMeter
+}
+
 // InitHttpMetrics TODO: The init function may be executed after the HttpServerOperationListener() method
 // so we need to make sure the otel_setup is executed before all the init() function
 // related to issue https://github.com/alibaba/loongsuite-go-agent/issues/48
@@ -83,11 +120,11 @@ func newHttpServerMetric(key string, meter metric.Meter) (*HttpServerMetric, err
 	m := &HttpServerMetric{
 		key: attribute.Key(key),
 	}
-	d, err := newHttpServerRequestDurationMeasures(meter)
-	if err != nil {
+	if err := m.instruments.Create(func() error {
+		return m.createInstruments(meter)
+	}); err != nil {
 		return nil, err
 	}
-	m.serverRequestDuration = d
 	return m, nil
 }
 
@@ -112,11 +149,11 @@ func newHttpClientMetric(key string, meter metric.Meter) (*HttpClientMetric, err
 	m := &HttpClientMetric{
 		key: attribute.Key(key),
 	}
-	d, err := newHttpClientRequestDurationMeasures(meter)
-	if err != nil {
+	if err := m.instruments.Create(func() error {
+		return m.createInstruments(meter)
+	}); err != nil {
 		return nil, err
 	}
-	m.clientRequestDuration = d
 	return m, nil
 }
 
@@ -136,9 +173,100 @@ func newHttpClientRequestDurationMeasures(meter metric.Meter) (metric.Float64His
 	}
 }
 
+func newHttpBodySizeMeasures(meter metric.Meter, name, description string) (metric.Int64Histogram, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	d, err := meter.Int64Histogram(name,
+		metric.WithUnit("By"),
+		metric.WithDescription(description))
+	if err == nil {
+		return d, nil
+	} else {
+		return d, errors.New(fmt.Sprintf("failed to create %s histogram, %v", name, err))
+	}
+}
+
+func newHttpActiveRequestsMeasures(meter metric.Meter, name, description string) (metric.Int64UpDownCounter, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	c, err := meter.Int64UpDownCounter(name,
+		metric.WithUnit("{request}"),
+		metric.WithDescription(description))
+	if err == nil {
+		return c, nil
+	} else {
+		return c, errors.New(fmt.Sprintf("failed to create %s counter, %v", name, err))
+	}
+}
+
 type httpMetricContext struct {
 	startTime       time.Time
 	startAttributes []attribute.KeyValue
+	activeAttrs     attribute.Set
+	// activeCounted tells if the request was added to the active requests
+	activeCounted bool
+}
+
+// activeRequestsAttrs picks the attributes of the active requests metrics out
+// of the start attributes without reordering them.
+func activeRequestsAttrs(startAttributes []attribute.KeyValue) attribute.Set {
+	attrs := make([]attribute.KeyValue, 0, len(httpActiveRequestsConv))
+	for _, attr := range startAttributes {
+		if httpActiveRequestsConv[attr.Key] {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attribute.NewSet(attrs...)
+}
+
+// bodySizes finds the body sizes of the request and response in the
+// attributes, -1 means unknown.
+func bodySizes(attributes []attribute.KeyValue) (int64, int64) {
+	requestSize, responseSize := int64(-1), int64(-1)
+	for _, attr := range attributes {
+		switch attr.Key {
+		case semconv.HTTPRequestBodySizeKey:
+			requestSize = attr.Value.AsInt64()
+		case semconv.HTTPResponseBodySizeKey:
+			responseSize = attr.Value.AsInt64()
+		}
+	}
+	return requestSize, responseSize
+}
+
+// createInstruments creates all instruments of the metric, the request
+// duration is always created first.
+func (h *HttpServerMetric) createInstruments(meter metric.Meter) error {
+	var err error
+	if h.serverRequestDuration, err = newHttpServerRequestDurationMeasures(meter); err != nil {
+		return err
+	}
+	if h.serverRequestBodySize, err = newHttpBodySizeMeasures(meter, http_server_request_body_size, "Size of HTTP server request bodies."); err != nil {
+		return err
+	}
+	if h.serverResponseBodySize, err = newHttpBodySizeMeasures(meter, http_server_response_body_size, "Size of HTTP server response bodies."); err != nil {
+		return err
+	}
+	if h.serverActiveRequests, err = newHttpActiveRequestsMeasures(meter, http_server_active_requests, "Number of active HTTP server requests."); err != nil {
+		return err
+	}
+	return nil
+}
+
+// initInstruments reports whether the instruments can be used, they are
+// created by the first call with a meter available.
+func (h *HttpServerMetric) initInstruments() bool {
+	return h.instruments.Do(currentMeter, func(meter metric.Meter) {
+		if err := h.createInstruments(meter); err != nil {
+			log.Printf("failed to create http server metrics, err is %v\n", err)
+		}
+	})
 }
 
 func (h *HttpServerMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
@@ -146,10 +274,16 @@ func (h *HttpServerMetric) OnBeforeStart(parentContext context.Context, startTim
 }
 
 func (h *HttpServerMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
-	return context.WithValue(ctx, h.key, httpMetricContext{
+	mc := httpMetricContext{
 		startTime:       startTime,
 		startAttributes: startAttributes,
-	})
+		activeAttrs:     activeRequestsAttrs(startAttributes),
+	}
+	if h.initInstruments() && h.serverActiveRequests != nil {
+		mc.activeCounted = true
+		h.serverActiveRequests.Add(ctx, 1, metric.WithAttributeSet(mc.activeAttrs))
+	}
+	return context.WithValue(ctx, h.key, mc)
 }
 
 func (h *HttpServerMetric) OnAfterStart(context context.Context, endTime time.Time) {
@@ -160,50 +294,98 @@ func (h *HttpServerMetric) OnAfterEnd(context context.Context, endAttributes []a
 	mc := context.Value(h.key).(httpMetricContext)
 	startTime, startAttributes := mc.startTime, mc.startAttributes
 	// end attributes should be shadowed by AttrsShadower
-	if h.serverRequestDuration == nil {
-		var err error
-		h.serverRequestDuration, err = newHttpServerRequestDurationMeasures(globalMeter)
-		if err != nil {
-			log.Printf("failed to create serverRequestDuration, err is %v\n", err)
-		}
+	if !h.initInstruments() {
+		return
 	}
+	if mc.activeCounted {
+		h.serverActiveRequests.Add(context, -1, metric.WithAttributeSet(mc.activeAttrs))
+	}
+	requestSize, responseSize := bodySizes(endAttributes)
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, httpMetricsConv)
+	attrSet := attribute.NewSet(metricsAttrs[0:n]...)
 	if h.serverRequestDuration != nil {
-		h.serverRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		h.serverRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attrSet))
+	}
+	if h.serverRequestBodySize != nil && requestSize >= 0 {
+		h.serverRequestBodySize.Record(context, requestSize, metric.WithAttributeSet(attrSet))
 	}
+	if h.serverResponseBodySize != nil && responseSize >= 0 {
+		h.serverResponseBodySize.Record(context, responseSize, metric.WithAttributeSet(attrSet))
+	}
+}
+
+// createInstruments creates all instruments of the metric, the request
+// duration is always created first.
+func (h *HttpClientMetric) createInstruments(meter metric.Meter) error {
+	var err error
+	if h.clientRequestDuration, err = newHttpClientRequestDurationMeasures(meter); err != nil {
+		return err
+	}
+	if h.clientRequestBodySize, err = newHttpBodySizeMeasures(meter, http_client_request_body_size, "Size of HTTP client request bodies."); err != nil {
+		return err
+	}
+	if h.clientResponseBodySize, err = newHttpBodySizeMeasures(meter, http_client_response_body_size, "Size of HTTP client response bodies."); err != nil {
+		return err
+	}
+	if h.clientActiveRequests, err = newHttpActiveRequestsMeasures(meter, http_client_active_requests, "Number of active HTTP client requests."); err != nil {
+		return err
+	}
+	return nil
+}
+
+// initInstruments reports whether the instruments can be used, they are
+// created by the first call with a meter available.
+func (h *HttpClientMetric) initInstruments() bool {
+	return h.instruments.Do(currentMeter, func(meter metric.Meter) {
+		if err := h.createInstruments(meter); err != nil {
+			log.Printf("failed to create http client metrics, err is %v\n", err)
+		}
+	})
 }
 
-func (h HttpClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+func (h *HttpClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
 	return parentContext
 }
 
-func (h HttpClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
-	return context.WithValue(ctx, h.key, httpMetricContext{
+func (h *HttpClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	mc := httpMetricContext{
 		startTime:       startTime,
 		startAttributes: startAttributes,
-	})
+		activeAttrs:     activeRequestsAttrs(startAttributes),
+	}
+	if h.initInstruments() && h.clientActiveRequests != nil {
+		mc.activeCounted = true
+		h.clientActiveRequests.Add(ctx, 1, metric.WithAttributeSet(mc.activeAttrs))
+	}
+	return context.WithValue(ctx, h.key, mc)
 }
 
-func (h HttpClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
+func (h *HttpClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
 	return
 }
 
-func (h HttpClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+func (h *HttpClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
 	mc := context.Value(h.key).(httpMetricContext)
 	startTime, startAttributes := mc.startTime, mc.startAttributes
 	// end attributes should be shadowed by AttrsShadower
-	if h.clientRequestDuration == nil {
-		var err error
-		// second change to init the metric
-		h.clientRequestDuration, err = newHttpClientRequestDurationMeasures(globalMeter)
-		if err != nil {
-			log.Printf("failed to create clientRequestDuration, err is %v\n", err)
-		}
+	if !h.initInstruments() {
+		return
+	}
+	if mc.activeCounted {
+		h.clientActiveRequests.Add(context, -1, metric.WithAttributeSet(mc.activeAttrs))
 	}
+	requestSize, responseSize := bodySizes(endAttributes)
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, httpMetricsConv)
+	attrSet := attribute.NewSet(metricsAttrs[0:n]...)
 	if h.clientRequestDuration != nil {
-		h.clientRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		h.clientRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attrSet))
+	}
+	if h.clientRequestBodySize != nil && requestSize >= 0 {
+		h.clientRequestBodySize.Record(context, requestSize, metric.WithAttributeSet(attrSet))
+	}
+	if h.clientResponseBodySize != nil && responseSize >= 0 {
+		h.clientResponseBodySize.Record(context, responseSize, metric.WithAttributeSet(attrSet))
 	}
 }
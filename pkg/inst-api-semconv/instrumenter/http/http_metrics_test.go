@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"sync"
 	"testing"
 	"time"
 )
@@ -225,3 +226,111 @@ func TestServerNilMeter(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestHttpServerBodySizeAndActiveRequests(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	server, err := newHttpServerMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	startAttrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String("POST"), semconv.URLSchemeKey.String("http")}
+	ctx = server.OnBeforeStart(ctx, start)
+	ctx = server.OnBeforeEnd(ctx, startAttrs, start)
+	server.OnAfterStart(ctx, start)
+
+	active := func() int64 {
+		rm := &metricdata.ResourceMetrics{}
+		if err := reader.Collect(context.Background(), rm); err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			if m.Name == "http.server.active_requests" {
+				return m.Data.(metricdata.Sum[int64]).DataPoints[0].Value
+			}
+		}
+		t.Fatal("no active requests metric")
+		return 0
+	}
+	if n := active(); n != 1 {
+		t.Fatalf("active requests = %d; expected 1", n)
+	}
+	server.OnAfterEnd(ctx, []attribute.KeyValue{
+		semconv.HTTPRequestBodySizeKey.Int(10),
+		semconv.HTTPResponseBodySizeKey.Int(20),
+	}, time.Now())
+	if n := active(); n != 0 {
+		t.Fatalf("active requests = %d; expected 0", n)
+	}
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	sizes := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if h, ok := m.Data.(metricdata.Histogram[int64]); ok {
+			sizes[m.Name] = h.DataPoints[0].Sum
+		}
+	}
+	if sizes["http.server.request.body.size"] != 10 || sizes["http.server.response.body.size"] != 20 {
+		t.Errorf("unexpected body sizes %v", sizes)
+	}
+}
+
+func TestHttpClientMetricsWithoutBodySize(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	client, err := newHttpClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{}, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "http.client.request.body.size" || m.Name == "http.client.response.body.size" {
+			t.Errorf("unknown body size should not be recorded, got %s", m.Name)
+		}
+	}
+}
+
+func TestHttpServerMetricsConcurrentRequests(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitHttpMetrics(nil)
+	server := HttpServerMetrics("net.http.server")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		if i == 10 {
+			// the meter turns up while requests are in flight
+			InitHttpMetrics(mp.Meter("test-meter"))
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			ctx := server.OnBeforeEnd(context.Background(), []attribute.KeyValue{}, start)
+			server.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+		}()
+	}
+	wg.Wait()
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "http.server.active_requests" {
+			continue
+		}
+		if v := m.Data.(metricdata.Sum[int64]).DataPoints[0].Value; v != 0 {
+			t.Errorf("active requests = %d; expected 0", v)
+		}
+	}
+}
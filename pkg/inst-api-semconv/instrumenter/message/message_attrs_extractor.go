@@ -16,6 +16,7 @@ package message
 
 import (
 	"context"
+	"fmt"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
@@ -108,6 +109,14 @@ func (m *MessageAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attributes []at
 		Key:   semconv.MessagingBatchMessageCountKey,
 		Value: attribute.Int64Value(m.Getter.GetBatchMessageCount(request, response)),
 	})
-	// TODO: add custom captured headers attributes
+	if err != nil {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   semconv.ErrorTypeKey,
+			Value: attribute.StringValue(fmt.Sprintf("%T", err)),
+		})
+	}
+	attributes = appendCapturedHeaders(attributes, func(name string) []string {
+		return m.Getter.GetMessageHeader(request, name)
+	})
 	return attributes, context
 }
@@ -16,6 +16,7 @@ package message
 
 import (
 	"context"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
@@ -178,3 +179,41 @@ func TestMessageClientExtractorEnd(t *testing.T) {
 		t.Fatalf("messaging batch message count should be 2024")
 	}
 }
+
+func TestMessageClientExtractorEndWithCapturedHeaders(t *testing.T) {
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_MESSAGING_CAPTURE_HEADERS", "X-Tenant-Id")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	messageExtractor := MessageAttrsExtractor[testRequest, testResponse, messageAttrsGetter]{}
+	attrs := make([]attribute.KeyValue, 0)
+	parentContext := context.Background()
+	attrs, _ = messageExtractor.OnEnd(attrs, parentContext, testRequest{}, testResponse{}, nil)
+	if len(attrs) != 3 {
+		t.Fatalf("expect 3 attributes, got %v", attrs)
+	}
+	if attrs[2].Key != "messaging.header.x-tenant-id" {
+		t.Fatalf("header attribute should be messaging.header.x-tenant-id, got %s", attrs[2].Key)
+	}
+	if values := attrs[2].Value.AsStringSlice(); len(values) != 2 || values[0] != "header1" || values[1] != "header2" {
+		t.Fatalf("header values should be header1 and header2, got %v", values)
+	}
+}
+
+func TestMessageClientExtractorEndRedactsSensitiveHeaders(t *testing.T) {
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_MESSAGING_CAPTURE_HEADERS", "Authorization")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	messageExtractor := MessageAttrsExtractor[testRequest, testResponse, messageAttrsGetter]{}
+	attrs := make([]attribute.KeyValue, 0)
+	attrs, _ = messageExtractor.OnEnd(attrs, context.Background(), testRequest{}, testResponse{}, nil)
+	if len(attrs) != 3 {
+		t.Fatalf("expect 3 attributes, got %v", attrs)
+	}
+	if values := attrs[2].Value.AsStringSlice(); len(values) != 2 || values[0] != "REDACTED" || values[1] != "REDACTED" {
+		t.Fatalf("authorization header should be redacted, got %v", values)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const messageHeaderPrefix = "messaging.header."
+
+var capturedHeaders = config.StringSlice("messaging", "capture-headers", nil, "Message headers captured as span attributes by messaging instrumentations.")
+
+// appendCapturedHeaders appends the messaging.header.<name> attributes of the
+// configured headers that are present on the message. Values of the sensitive
+// HTTP headers are redacted, message headers often carry the same credentials.
+func appendCapturedHeaders(attributes []attribute.KeyValue, getter func(name string) []string) []attribute.KeyValue {
+	for _, name := range capturedHeaders.Get() {
+		values := getter(name)
+		if len(values) == 0 {
+			continue
+		}
+		attributes = append(attributes, attribute.StringSlice(messageHeaderPrefix+strings.ToLower(name), http.RedactSensitiveHeader(name, values)))
+	}
+	return attributes
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/utils"
+	instutils "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const messaging_client_operation_duration = "messaging.client.operation.duration"
+
+const messaging_client_sent_messages = "messaging.client.sent.messages"
+
+const messaging_client_consumed_messages = "messaging.client.consumed.messages"
+
+type MessageMetric struct {
+	key               attribute.Key
+	operationDuration metric.Float64Histogram
+	sentMessages      metric.Int64Counter
+	consumedMessages  metric.Int64Counter
+	instruments       instutils.InstrumentsOnce
+}
+
+var mu sync.Mutex
+
+var messageMetricsConv = map[attribute.Key]bool{
+	semconv.MessagingOperationNameKey:          true,
+	semconv.MessagingSystemKey:                 true,
+	semconv.MessagingDestinationNameKey:        true,
+	semconv.MessagingDestinationTemplateKey:    true,
+	semconv.MessagingDestinationPartitionIDKey: true,
+	semconv.MessagingConsumerGroupNameKey:      true,
+	semconv.ErrorTypeKey:                       true,
+	semconv.ServerAddressKey:                   true,
+	semconv.ServerPortKey:                      true,
+}
+
+var globalMeter metric.Meter
+
+// currentMeter returns the meter set by the otel setup, nil before it ran.
+func currentMeter() metric.Meter {
+	mu.Lock()
+	defer mu.Unlock()
+	return globalMeter
+}
+
+// InitMessageMetrics sets the meter of the messaging metrics. The otel setup
+// may run after the instrumentations created their metrics, see
+// https://github.com/alibaba/loongsuite-go-agent/issues/48, so the
+// instruments are created with the first message handled after it ran.
+func InitMessageMetrics(m metric.Meter) {
+	mu.Lock()
+	defer mu.Unlock()
+	globalMeter = m
+}
+
+func MessageMetrics(key string) *MessageMetric {
+	mu.Lock()
+	defer mu.Unlock()
+	return &MessageMetric{key: attribute.Key(key)}
+}
+
+// for test only
+func newMessageMetric(key string, meter metric.Meter) (*MessageMetric, error) {
+	m := &MessageMetric{
+		key: attribute.Key(key),
+	}
+	if err := m.instruments.Create(func() error {
+		return m.createInstruments(meter)
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func newMessageOperationDurationMeasures(meter metric.Meter) (metric.Float64Histogram, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	d, err := meter.Float64Histogram(messaging_client_operation_duration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Duration of messaging operation initiated by a producer or consumer client."))
+	if err == nil {
+		return d, nil
+	} else {
+		return d, errors.New(fmt.Sprintf("failed to create messaging.client.operation.duration histogram, %v", err))
+	}
+}
+
+func newMessageCountMeasures(meter metric.Meter, name, description string) (metric.Int64Counter, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	c, err := meter.Int64Counter(name,
+		metric.WithUnit("{message}"),
+		metric.WithDescription(description))
+	if err == nil {
+		return c, nil
+	} else {
+		return c, errors.New(fmt.Sprintf("failed to create %s counter, %v", name, err))
+	}
+}
+
+type messageMetricContext struct {
+	startTime       time.Time
+	startAttributes []attribute.KeyValue
+	received        bool
+}
+
+// receivedKey marks the context of processing messages which were counted as
+// consumed by their receive spans already.
+type receivedKey struct{}
+
+// ContextWithReceived returns a copy of ctx for starting the process span of
+// a message that has a receive span, so that the message is consumed once in
+// messaging.client.consumed.messages. Process spans of messages without a
+// receive span count the messages themselves.
+func ContextWithReceived(ctx context.Context) context.Context {
+	return context.WithValue(ctx, receivedKey{}, true)
+}
+
+// messageCount finds the operation and the number of messages it handled in
+// the attributes, operations without a batch count handle a single message.
+func messageCount(attributes []attribute.KeyValue) (string, int64) {
+	operation, count := "", int64(1)
+	for _, attr := range attributes {
+		switch attr.Key {
+		case semconv.MessagingOperationNameKey:
+			operation = attr.Value.AsString()
+		case semconv.MessagingBatchMessageCountKey:
+			if c := attr.Value.AsInt64(); c > 0 {
+				count = c
+			}
+		}
+	}
+	return operation, count
+}
+
+// createInstruments creates all instruments of the metric, the operation
+// duration is always created first.
+func (m *MessageMetric) createInstruments(meter metric.Meter) error {
+	var err error
+	if m.operationDuration, err = newMessageOperationDurationMeasures(meter); err != nil {
+		return err
+	}
+	if m.sentMessages, err = newMessageCountMeasures(meter, messaging_client_sent_messages, "Number of messages producer attempted to send to the broker."); err != nil {
+		return err
+	}
+	if m.consumedMessages, err = newMessageCountMeasures(meter, messaging_client_consumed_messages, "Number of messages that were delivered to the application."); err != nil {
+		return err
+	}
+	return nil
+}
+
+// initInstruments reports whether the instruments can be used, they are
+// created by the first call with a meter available.
+func (m *MessageMetric) initInstruments() bool {
+	return m.instruments.Do(currentMeter, func(meter metric.Meter) {
+		if err := m.createInstruments(meter); err != nil {
+			log.Printf("failed to create message metrics, err is %v\n", err)
+		}
+	})
+}
+
+func (m *MessageMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+	return parentContext
+}
+
+func (m *MessageMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	return context.WithValue(ctx, m.key, messageMetricContext{
+		startTime:       startTime,
+		startAttributes: startAttributes,
+		received:        ctx.Value(receivedKey{}) != nil,
+	})
+}
+
+func (m *MessageMetric) OnAfterStart(context context.Context, endTime time.Time) {
+	return
+}
+
+func (m *MessageMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+	mc, ok := context.Value(m.key).(messageMetricContext)
+	if !ok {
+		return
+	}
+	startTime, startAttributes := mc.startTime, mc.startAttributes
+	if !m.initInstruments() {
+		return
+	}
+	// end attributes should be shadowed by AttrsShadower
+	endAttributes = append(endAttributes, startAttributes...)
+	operation, count := messageCount(endAttributes)
+	n, metricsAttrs := utils.Shadow(endAttributes, messageMetricsConv)
+	attrSet := attribute.NewSet(metricsAttrs[0:n]...)
+	if m.operationDuration != nil {
+		m.operationDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attrSet))
+	}
+	switch MessageOperation(operation) {
+	case PUBLISH:
+		if m.sentMessages != nil {
+			m.sentMessages.Add(context, count, metric.WithAttributeSet(attrSet))
+		}
+	case RECEIVE:
+		if m.consumedMessages != nil {
+			m.consumedMessages.Add(context, count, metric.WithAttributeSet(attrSet))
+		}
+	case PROCESS:
+		if m.consumedMessages != nil && !mc.received {
+			m.consumedMessages.Add(context, count, metric.WithAttributeSet(attrSet))
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func collectMessageMetrics(t *testing.T, operation MessageOperation, count int64) map[string]metricdata.Aggregation {
+	reader := metric.NewManualReader()
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("my-service"),
+		semconv.ServiceVersion("v0.1.0"),
+	)
+	mp := metric.NewMeterProvider(metric.WithResource(res), metric.WithReader(reader))
+	meter := mp.Meter("test-meter")
+	m, err := newMessageMetric("test", meter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = m.OnBeforeStart(ctx, start)
+	ctx = m.OnBeforeEnd(ctx, []attribute.KeyValue{
+		semconv.MessagingSystemKafka,
+		semconv.MessagingOperationName(string(operation)),
+		semconv.MessagingDestinationName("topic"),
+	}, start)
+	m.OnAfterStart(ctx, start)
+	m.OnAfterEnd(ctx, []attribute.KeyValue{semconv.MessagingBatchMessageCount(int(count))}, time.Now())
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]metricdata.Aggregation)
+	for _, md := range rm.ScopeMetrics[0].Metrics {
+		result[md.Name] = md.Data
+	}
+	return result
+}
+
+func sumValue(t *testing.T, data metricdata.Aggregation) int64 {
+	sum, ok := data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 {
+		t.Fatalf("unexpected sum data %v", data)
+	}
+	return sum.DataPoints[0].Value
+}
+
+func TestMessagePublishMetrics(t *testing.T) {
+	result := collectMessageMetrics(t, PUBLISH, 3)
+	if _, ok := result["messaging.client.operation.duration"]; !ok {
+		t.Fatal("expect messaging.client.operation.duration")
+	}
+	if v := sumValue(t, result["messaging.client.sent.messages"]); v != 3 {
+		t.Fatalf("expect 3 sent messages, got %d", v)
+	}
+	if _, ok := result["messaging.client.consumed.messages"]; ok {
+		t.Fatal("expect no consumed messages for publish")
+	}
+}
+
+func TestMessageConsumeMetrics(t *testing.T) {
+	result := collectMessageMetrics(t, PROCESS, 0)
+	if v := sumValue(t, result["messaging.client.consumed.messages"]); v != 1 {
+		t.Fatalf("expect 1 consumed message, got %d", v)
+	}
+	if _, ok := result["messaging.client.sent.messages"]; ok {
+		t.Fatal("expect no sent messages for process")
+	}
+}
+
+func TestMessageMetricsWithoutContext(t *testing.T) {
+	m := MessageMetrics("test")
+	m.OnAfterEnd(context.Background(), []attribute.KeyValue{}, time.Now())
+}
+
+func TestMessageMetricsConcurrentMessages(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitMessageMetrics(nil)
+	t.Cleanup(func() { InitMessageMetrics(nil) })
+	m := MessageMetrics("test")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		if i == 10 {
+			// the meter turns up while messages are in flight
+			InitMessageMetrics(mp.Meter("test-meter"))
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			ctx := m.OnBeforeEnd(context.Background(), []attribute.KeyValue{
+				semconv.MessagingOperationName(string(PUBLISH)),
+			}, start)
+			m.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+		}()
+	}
+	wg.Wait()
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, md := range rm.ScopeMetrics[0].Metrics {
+		if md.Name != messaging_client_sent_messages {
+			continue
+		}
+		if v := sumValue(t, md.Data); v < 10 || v > 20 {
+			t.Errorf("sent messages = %d; expected between 10 and 20", v)
+		}
+	}
+}
+
+func TestMessageReceivedAndProcessedOnce(t *testing.T) {
+	reader := metric.NewManualReader()
+	meter := metric.NewMeterProvider(metric.WithReader(reader)).Meter("test-meter")
+	receive, err := newMessageMetric("test.receive", meter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	process, err := newMessageMetric("test.process", meter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := func(m *MessageMetric, ctx context.Context, operation MessageOperation) {
+		start := time.Now()
+		ctx = m.OnBeforeEnd(m.OnBeforeStart(ctx, start), []attribute.KeyValue{
+			semconv.MessagingOperationName(string(operation)),
+		}, start)
+		m.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	}
+	// the message is received and then processed
+	record(receive, context.Background(), RECEIVE)
+	record(process, ContextWithReceived(context.Background()), PROCESS)
+	// the message is only processed, e.g. it is pushed to a handler
+	record(process, context.Background(), PROCESS)
+
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, md := range rm.ScopeMetrics[0].Metrics {
+		if md.Name != messaging_client_consumed_messages {
+			continue
+		}
+		sum := md.Data.(metricdata.Sum[int64])
+		var total int64
+		for _, dp := range sum.DataPoints {
+			total += dp.Value
+		}
+		if total != 2 {
+			t.Fatalf("consumed messages = %d; expected 2", total)
+		}
+		return
+	}
+	t.Fatal("expect messaging.client.consumed.messages")
+}
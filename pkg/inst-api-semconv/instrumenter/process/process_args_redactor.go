@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveNames are the substrings of flag and variable names whose values
+// are redacted from the recorded command arguments
+var sensitiveNames = []string{
+	"password", "passwd", "pwd", "secret", "token", "credential",
+	"apikey", "api-key", "api_key", "access-key", "access_key",
+	"private-key", "private_key", "auth",
+}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(strings.TrimLeft(name, "-"))
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactCommandArgs masks the values of sensitive flags and assignments, in
+// the forms "--password=x", "--password x" and "PASSWORD=x", as well as the
+// passwords of URLs. The arguments are copied rather than modified.
+func RedactCommandArgs(args []string) []string {
+	res := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case redactNext && !strings.HasPrefix(arg, "-"):
+			res[i] = redacted
+		case strings.Contains(arg, "://"):
+			res[i] = redactURL(arg)
+		default:
+			res[i] = arg
+			if name, _, found := strings.Cut(arg, "="); found {
+				if isSensitive(name) {
+					res[i] = name + "=" + redacted
+				}
+			}
+		}
+		redactNext = i > 0 && strings.HasPrefix(arg, "-") &&
+			!strings.Contains(arg, "=") && isSensitive(arg)
+	}
+	return res
+}
+
+func redactURL(arg string) string {
+	u, err := url.Parse(arg)
+	if err != nil || u.User == nil {
+		return arg
+	}
+	if _, ok := u.User.Password(); !ok {
+		return arg
+	}
+	u.User = url.UserPassword(u.User.Username(), redacted)
+	return u.String()
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+// ProcessAttrsExtractor records a child process started by the application.
+// Its arguments are redacted by RedactCommandArgs.
+type ProcessAttrsExtractor[REQUEST any, RESPONSE any, GETTER ProcessAttrsGetter[REQUEST, RESPONSE]] struct {
+	Getter GETTER
+}
+
+func (e *ProcessAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request REQUEST) ([]attribute.KeyValue, context.Context) {
+	attributes = append(attributes, semconv.ProcessCommand(e.Getter.GetCommand(request)))
+	if args := e.Getter.GetCommandArgs(request); len(args) > 0 {
+		attributes = append(attributes, semconv.ProcessCommandArgs(RedactCommandArgs(args)...))
+	}
+	return attributes, parentContext
+}
+
+func (e *ProcessAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attributes []attribute.KeyValue, context context.Context, request REQUEST, response RESPONSE, err error) ([]attribute.KeyValue, context.Context) {
+	if pid := e.Getter.GetPid(request, response); pid > 0 {
+		attributes = append(attributes, semconv.ProcessPID(pid))
+	}
+	if code := e.Getter.GetExitCode(request, response); code >= 0 {
+		attributes = append(attributes, semconv.ProcessExitCode(code))
+	}
+	return attributes, context
+}
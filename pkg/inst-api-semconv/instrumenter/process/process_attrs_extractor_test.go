@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type testProcessRequest struct {
+	Command string
+	Args    []string
+}
+
+type testProcessResponse struct {
+	Pid      int
+	ExitCode int
+}
+
+type mockProcessAttrsGetter struct{}
+
+func (m mockProcessAttrsGetter) GetCommand(request testProcessRequest) string {
+	return request.Command
+}
+
+func (m mockProcessAttrsGetter) GetCommandArgs(request testProcessRequest) []string {
+	return request.Args
+}
+
+func (m mockProcessAttrsGetter) GetPid(request testProcessRequest, response testProcessResponse) int {
+	return response.Pid
+}
+
+func (m mockProcessAttrsGetter) GetExitCode(request testProcessRequest, response testProcessResponse) int {
+	return response.ExitCode
+}
+
+func TestProcessAttrsExtractorOnStart(t *testing.T) {
+	extractor := ProcessAttrsExtractor[testProcessRequest, testProcessResponse, mockProcessAttrsGetter]{}
+	request := testProcessRequest{
+		Command: "/usr/bin/worker",
+		Args:    []string{"worker", "--token", "abc", "run"},
+	}
+	attrs, _ := extractor.OnStart(nil, context.Background(), request)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("process.command", "/usr/bin/worker"),
+		attribute.StringSlice("process.command_args", []string{"worker", "--token", "REDACTED", "run"}),
+	}, attrs)
+	assert.Equal(t, []string{"worker", "--token", "abc", "run"}, request.Args)
+}
+
+func TestProcessAttrsExtractorOnEnd(t *testing.T) {
+	extractor := ProcessAttrsExtractor[testProcessRequest, testProcessResponse, mockProcessAttrsGetter]{}
+	tests := []struct {
+		name     string
+		response testProcessResponse
+		expected []attribute.KeyValue
+	}{
+		{
+			name:     "exited",
+			response: testProcessResponse{Pid: 42, ExitCode: 3},
+			expected: []attribute.KeyValue{
+				attribute.Int("process.pid", 42),
+				attribute.Int("process.exit.code", 3),
+			},
+		},
+		{
+			name:     "not started",
+			response: testProcessResponse{Pid: 0, ExitCode: -1},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs, _ := extractor.OnEnd(nil, context.Background(), testProcessRequest{}, tt.response, nil)
+			assert.Equal(t, tt.expected, attrs)
+		})
+	}
+}
+
+func TestProcessSpanNameExtractor(t *testing.T) {
+	extractor := ProcessSpanNameExtractor[testProcessRequest, testProcessResponse]{Getter: mockProcessAttrsGetter{}}
+	assert.Equal(t, "exec worker", extractor.Extract(testProcessRequest{Command: "/usr/bin/worker"}))
+	assert.Equal(t, "exec", extractor.Extract(testProcessRequest{}))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// EnvCarrier carries the context across the process boundary in environment
+// variables of the form "KEY=value", i.e. TRACEPARENT, TRACESTATE and BAGGAGE.
+// Keys are upper cased, as environment variables conventionally are.
+type EnvCarrier struct {
+	Env []string
+}
+
+var _ propagation.TextMapCarrier = &EnvCarrier{}
+
+// Get returns the value of the last assignment of the key, as later
+// assignments take precedence when a process is started
+func (c *EnvCarrier) Get(key string) string {
+	prefix := strings.ToUpper(key) + "="
+	for i := len(c.Env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(c.Env[i], prefix) {
+			return c.Env[i][len(prefix):]
+		}
+	}
+	return ""
+}
+
+// Set replaces every assignment of the key, e.g. the context the process
+// itself was started with
+func (c *EnvCarrier) Set(key string, value string) {
+	prefix := strings.ToUpper(key) + "="
+	env := make([]string, 0, len(c.Env)+1)
+	for _, kv := range c.Env {
+		if !strings.HasPrefix(kv, prefix) {
+			env = append(env, kv)
+		}
+	}
+	c.Env = append(env, prefix+value)
+}
+
+func (c *EnvCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.Env))
+	for _, kv := range c.Env {
+		if k, _, found := strings.Cut(kv, "="); found {
+			keys = append(keys, strings.ToLower(k))
+		}
+	}
+	return keys
+}
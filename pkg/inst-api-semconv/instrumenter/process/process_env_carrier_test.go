@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEnvCarrier(t *testing.T) {
+	env := []string{"PATH=/bin", "TRACEPARENT=old", "TRACEPARENT=older"}
+	carrier := &EnvCarrier{Env: env}
+	assert.Equal(t, "older", carrier.Get("traceparent"))
+	assert.Equal(t, "", carrier.Get("tracestate"))
+
+	carrier.Set("traceparent", "new")
+	assert.Equal(t, []string{"PATH=/bin", "TRACEPARENT=new"}, carrier.Env)
+	assert.Equal(t, []string{"path", "traceparent"}, carrier.Keys())
+	assert.Equal(t, []string{"PATH=/bin", "TRACEPARENT=old", "TRACEPARENT=older"}, env)
+}
+
+func TestEnvCarrierPropagation(t *testing.T) {
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	member, _ := baggage.NewMember("tenant", "a")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(trace.ContextWithSpanContext(context.Background(), sc), bag)
+
+	carrier := &EnvCarrier{Env: []string{"PATH=/bin"}}
+	propagator.Inject(ctx, carrier)
+	assert.Contains(t, carrier.Env, "TRACEPARENT=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.Contains(t, carrier.Env, "BAGGAGE=tenant=a")
+
+	extracted := propagator.Extract(context.Background(), &EnvCarrier{Env: carrier.Env})
+	assert.Equal(t, sc.TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+	assert.Equal(t, sc.SpanID(), trace.SpanContextFromContext(extracted).SpanID())
+	assert.True(t, trace.SpanContextFromContext(extracted).IsRemote())
+	assert.Equal(t, "a", baggage.FromContext(extracted).Member("tenant").Value())
+}
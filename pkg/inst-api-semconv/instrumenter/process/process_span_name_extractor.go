@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"path/filepath"
+)
+
+type ProcessSpanNameExtractor[REQUEST any, RESPONSE any] struct {
+	Getter ProcessAttrsGetter[REQUEST, RESPONSE]
+}
+
+// Extract names the span after the executable, e.g. "exec git"
+func (p *ProcessSpanNameExtractor[REQUEST, RESPONSE]) Extract(request REQUEST) string {
+	command := p.Getter.GetCommand(request)
+	if command == "" {
+		return "exec"
+	}
+	return "exec " + filepath.Base(command)
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+)
+
+// -----------------------------------------------------------------------------
+// Instrumentation Configuration
+//
+// Every instrumentation setting is identified by a key of the form
+// otel.instrumentation.<instrumentation>.<option>, e.g.
+// otel.instrumentation.redis.enabled. The key maps to an environment variable
+// by upper-casing it and replacing "." and "-" with "_", which gives
+// OTEL_INSTRUMENTATION_REDIS_ENABLED. A value is resolved in the following
+// order, the first hit wins:
+//   - the environment variable derived from the key
+//   - legacy environment variables registered as aliases of the option
+//   - the config file designated by OTEL_INSTRUMENTATION_CONFIG_FILE
+//   - the default value of the option
+//
+// The config file is a properties file, one "key=value" per line. Keys can be
+// written either in the dotted form or in the environment variable form, and
+// lines starting with "#" or "!" are comments. The file can be reloaded at
+// runtime, see Reload, so that switches and tunables take effect for new
+// operations without restarting the process.
+
+const (
+	EnvConfigFile  = "OTEL_INSTRUMENTATION_CONFIG_FILE"
+	EnvConfigPrint = "OTEL_INSTRUMENTATION_CONFIG_PRINT"
+	keyPrefix      = "otel.instrumentation."
+)
+
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+)
+
+// Setting is a snapshot of a registered option and its current value.
+type Setting struct {
+	Key         string
+	Env         string
+	Aliases     []string
+	Type        string
+	Default     string
+	Value       string
+	Source      string
+	Description string
+}
+
+type option interface {
+	setting() Setting
+	// resolve updates the value of the option and reports whether it changed
+	resolve() bool
+}
+
+var (
+	registryLock sync.Mutex
+	registry     = make(map[string]option)
+	fileLoadOnce sync.Once
+	fileValues   atomic.Pointer[map[string]string]
+)
+
+// Key returns the canonical key of an option of the instrumentation.
+func Key(instrumentation, name string) string {
+	return keyPrefix + strings.ToLower(instrumentation) + "." + strings.ToLower(name)
+}
+
+// EnvName converts a key to its environment variable name.
+func EnvName(key string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(key))
+}
+
+// register adds the option into the registry. If an option with the same key
+// is registered already, the existing one is returned so that packages that
+// share a setting, e.g. hertz client and server, also share its value.
+func register[T option](key string, create func() T) T {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if existing, ok := registry[key]; ok {
+		if o, ok := existing.(T); ok {
+			return o
+		}
+		log.Printf("instrumentation config %s is registered with another type", key)
+	}
+	o := create()
+	o.resolve()
+	registry[key] = o
+	return o
+}
+
+// lookup finds the raw value of the option, see the package comment for the
+// resolution order.
+func lookup(env string, aliases []string) (string, string, bool) {
+	if v, ok := os.LookupEnv(env); ok {
+		return v, SourceEnv, true
+	}
+	for _, alias := range aliases {
+		if v, ok := os.LookupEnv(alias); ok {
+			return v, SourceEnv, true
+		}
+	}
+	fileLoadOnce.Do(func() {
+		path := os.Getenv(EnvConfigFile)
+		if path == "" {
+			return
+		}
+		values, err := loadConfigFile(path)
+		if err != nil {
+			log.Printf("failed to load instrumentation config file %s: %v", path, err)
+			return
+		}
+		fileValues.Store(&values)
+	})
+	if values := fileValues.Load(); values != nil {
+		if v, ok := (*values)[env]; ok {
+			return v, SourceFile, true
+		}
+	}
+	return "", SourceDefault, false
+}
+
+func loadConfigFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return parseProperties(f)
+}
+
+// parseProperties parses the properties content, keys are normalized to the
+// environment variable form.
+func parseProperties(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		idx := strings.IndexAny(line, "=:")
+		if idx <= 0 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		values[EnvName(key)] = strings.TrimSpace(line[idx+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Settings lists all registered options along with their current values,
+// sorted by key.
+func Settings() []Setting {
+	registryLock.Lock()
+	settings := make([]Setting, 0, len(registry))
+	for _, o := range registry {
+		settings = append(settings, o.setting())
+	}
+	registryLock.Unlock()
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings
+}
+
+// Dump writes all registered options in a human-readable table.
+func Dump(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KEY\tENV\tTYPE\tVALUE\tSOURCE")
+	for _, s := range Settings() {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Key, s.Env, s.Type, s.Value, s.Source)
+	}
+	_ = tw.Flush()
+}
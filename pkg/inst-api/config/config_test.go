@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func resetConfigFile(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "otel.properties")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvConfigFile, path)
+	fileLoadOnce = sync.Once{}
+	fileValues.Store(nil)
+	t.Cleanup(func() {
+		fileLoadOnce = sync.Once{}
+		fileValues.Store(nil)
+	})
+}
+
+func TestKeyAndEnvName(t *testing.T) {
+	testCases := []struct {
+		instrumentation string
+		name            string
+		key             string
+		env             string
+	}{
+		{"redis", "enabled", "otel.instrumentation.redis.enabled", "OTEL_INSTRUMENTATION_REDIS_ENABLED"},
+		{"k8s-client-go", "enabled", "otel.instrumentation.k8s-client-go.enabled", "OTEL_INSTRUMENTATION_K8S_CLIENT_GO_ENABLED"},
+		{"db", "experimental.enable", "otel.instrumentation.db.experimental.enable", "OTEL_INSTRUMENTATION_DB_EXPERIMENTAL_ENABLE"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			if key := Key(tc.instrumentation, tc.name); key != tc.key {
+				t.Errorf("Key() = %s; expected %s", key, tc.key)
+			}
+			if env := EnvName(tc.key); env != tc.env {
+				t.Errorf("EnvName() = %s; expected %s", env, tc.env)
+			}
+			if env := EnvName(tc.env); env != tc.env {
+				t.Errorf("EnvName() should be idempotent, got %s", env)
+			}
+		})
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	content := `
+# comment
+! another comment
+otel.instrumentation.redis.enabled = false
+OTEL_INSTRUMENTATION_MONGO_ENABLED=true
+otel.instrumentation.http.capture-headers: a, b
+`
+	values, err := parseProperties(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"OTEL_INSTRUMENTATION_REDIS_ENABLED":        "false",
+		"OTEL_INSTRUMENTATION_MONGO_ENABLED":        "true",
+		"OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS": "a, b",
+	}
+	if len(values) != len(expected) {
+		t.Fatalf("unexpected values %v", values)
+	}
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("values[%s] = %q; expected %q", k, values[k], v)
+		}
+	}
+	if _, err = parseProperties(strings.NewReader("no separator")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestResolutionOrder(t *testing.T) {
+	resetConfigFile(t, "otel.instrumentation.test-order.a=file\n"+
+		"otel.instrumentation.test-order.b=file\n"+
+		"otel.instrumentation.test-order.c=file\n")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_ORDER_A", "env")
+	t.Setenv("TEST_ORDER_LEGACY_B", "alias")
+
+	a := String("test-order", "a", "default", "")
+	b := String("test-order", "b", "default", "", "TEST_ORDER_LEGACY_B")
+	c := String("test-order", "c", "default", "")
+	d := String("test-order", "d", "default", "")
+	for _, tc := range []struct {
+		option   *StringOption
+		expected string
+		source   string
+	}{
+		{a, "env", SourceEnv},
+		{b, "alias", SourceEnv},
+		{c, "file", SourceFile},
+		{d, "default", SourceDefault},
+	} {
+		if tc.option.Get() != tc.expected {
+			t.Errorf("%s = %s; expected %s", tc.option.Key(), tc.option.Get(), tc.expected)
+		}
+		if s := tc.option.setting(); s.Source != tc.source {
+			t.Errorf("%s source = %s; expected %s", tc.option.Key(), s.Source, tc.source)
+		}
+	}
+}
+
+func TestTypedOptions(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_TYPED_BOOL", "false")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_TYPED_INT", "42")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_TYPED_FLOAT", "0.25")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_TYPED_LIST", " a,,b , c")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_TYPED_BAD_INT", "not-a-number")
+
+	if Bool("test-typed", "bool", true, "").Get() {
+		t.Error("expected bool option to be false")
+	}
+	if v := Int("test-typed", "int", 1, "").Get(); v != 42 {
+		t.Errorf("int option = %d; expected 42", v)
+	}
+	if v := Float("test-typed", "float", 1, "").Get(); v != 0.25 {
+		t.Errorf("float option = %f; expected 0.25", v)
+	}
+	if v := StringSlice("test-typed", "list", nil, "").Get(); strings.Join(v, "|") != "a|b|c" {
+		t.Errorf("list option = %v; expected [a b c]", v)
+	}
+	bad := Int("test-typed", "bad-int", 7, "")
+	if bad.Get() != 7 || bad.setting().Source != SourceDefault {
+		t.Errorf("invalid int option should fall back to default, got %d", bad.Get())
+	}
+}
+
+func TestEnabler(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_ENABLER_OFF_ENABLED", "false")
+	if !NewInstrumentationEnabler("test-enabler-on").Enable() {
+		t.Error("instrumentation should be enabled by default")
+	}
+	if NewInstrumentationEnabler("test-enabler-off").Enable() {
+		t.Error("instrumentation should be disabled")
+	}
+	// the same switch is shared between registrations
+	if NewInstrumentationEnabler("test-enabler-off").option != NewInstrumentationEnabler("test-enabler-off").option {
+		t.Error("expected the same option for the same instrumentation")
+	}
+}
+
+func TestSettingsListKnownInstrumentations(t *testing.T) {
+	found := make(map[string]Setting)
+	for _, s := range Settings() {
+		found[s.Key] = s
+	}
+	for name := range knownInstrumentations {
+		if _, ok := found[Key(name, "enabled")]; !ok {
+			t.Errorf("switch of %s is not listed", name)
+		}
+	}
+	kafka := found[Key("segmentio-kafka-go", "enabled")]
+	if len(kafka.Aliases) != 1 || kafka.Aliases[0] != "OTEL_SEGMENTIO_KAFKA_ENABLED" {
+		t.Errorf("unexpected aliases %v", kafka.Aliases)
+	}
+	buf := &bytes.Buffer{}
+	Dump(buf)
+	if !strings.Contains(buf.String(), "OTEL_INSTRUMENTATION_NETHTTP_ENABLED") {
+		t.Errorf("unexpected dump %s", buf.String())
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// InstrumentationEnabler reports whether an instrumentation is switched on by
+// otel.instrumentation.<name>.enabled. It satisfies the
+// instrumenter.InstrumentEnabler interface.
+type InstrumentationEnabler struct {
+	option *BoolOption
+}
+
+// NewInstrumentationEnabler returns the enabler of the instrumentation, which
+// is enabled by default. Aliases are legacy environment variables that are
+// still honored.
+func NewInstrumentationEnabler(name string, aliases ...string) InstrumentationEnabler {
+	return InstrumentationEnabler{
+		option: Bool(name, "enabled", true, "Enable the "+name+" instrumentation.", aliases...),
+	}
+}
+
+func (e InstrumentationEnabler) Enable() bool {
+	return e.option.Get()
+}
+
+// knownInstrumentations lists all instrumentations shipped with the agent so
+// that their switches are listed by Settings even if the corresponding rule is
+// not linked into the binary. The values are the legacy environment variables
+// of the switch, if any.
+var knownInstrumentations = map[string][]string{
+	"amqp091":            nil,
+	"ants":               nil,
+	"chi":                nil,
+	"connect":            nil,
+	"databasesql":        nil,
+	"dubbo":              nil,
+	"echo":               nil,
+	"eino":               nil,
+	"elasticsearch":      nil,
+	"fasthttp":           nil,
+	"fiberv2":            nil,
+	"franz-go":           nil,
+	"gin":                nil,
+	"glog":               nil,
+	"gocql":              nil,
+	"gokitlog":           nil,
+	"gomicro":            nil,
+	"gopg":               nil,
+	"gorestful":          nil,
+	"gorm":               nil,
+	"goslog":             nil,
+	"gozero":             nil,
+	"grpc":               nil,
+	"hertz":              nil,
+	"iris":               nil,
+	"k8s-client-go":      nil,
+	"kitex":              nil,
+	"langchain":          nil,
+	"logrus":             nil,
+	"mongo":              nil,
+	"mux":                nil,
+	"nats":               nil,
+	"nethttp":            nil,
+	"osexec":             nil,
+	"pgx":                nil,
+	"redigo":             nil,
+	"redisv8":            nil,
+	"redisv9":            nil,
+	"sarama":             nil,
+	"segmentio-kafka-go": {"OTEL_SEGMENTIO_KAFKA_ENABLED"},
+	"sqlx":               nil,
+	"trpc":               nil,
+	"zap":                nil,
+	"zerolog":            nil,
+}
+
+func init() {
+	for name, aliases := range knownInstrumentations {
+		NewInstrumentationEnabler(name, aliases...)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// baseOption holds the metadata of an option. The typed options keep their
+// values in atomics so that Get is cheap and safe while Reload is in progress.
+type baseOption struct {
+	key         string
+	env         string
+	aliases     []string
+	typ         string
+	def         string
+	description string
+
+	mu     sync.Mutex
+	raw    string
+	source string
+}
+
+func newBaseOption(instrumentation, name, typ, def, description string, aliases []string) *baseOption {
+	key := Key(instrumentation, name)
+	return &baseOption{
+		key:         key,
+		env:         EnvName(key),
+		aliases:     aliases,
+		typ:         typ,
+		def:         def,
+		description: description,
+	}
+}
+
+// lookupRaw resolves the raw value and reports whether it is set explicitly.
+func (b *baseOption) lookupRaw() (string, bool) {
+	raw, source, ok := lookup(b.env, b.aliases)
+	b.mu.Lock()
+	b.raw, b.source = raw, source
+	b.mu.Unlock()
+	return raw, ok
+}
+
+func (b *baseOption) invalid() {
+	b.mu.Lock()
+	raw := b.raw
+	b.source = SourceDefault
+	b.mu.Unlock()
+	log.Printf("invalid %s value %q for %s, fallback to %s", b.typ, raw, b.env, b.def)
+}
+
+func (b *baseOption) Key() string {
+	return b.key
+}
+
+func (b *baseOption) EnvName() string {
+	return b.env
+}
+
+func (b *baseOption) toSetting(value string) Setting {
+	b.mu.Lock()
+	source := b.source
+	b.mu.Unlock()
+	return Setting{
+		Key:         b.key,
+		Env:         b.env,
+		Aliases:     b.aliases,
+		Type:        b.typ,
+		Default:     b.def,
+		Value:       value,
+		Source:      source,
+		Description: b.description,
+	}
+}
+
+// BoolOption is a boolean instrumentation setting.
+type BoolOption struct {
+	*baseOption
+	defValue bool
+	value    atomic.Bool
+}
+
+// Bool registers a boolean option of the instrumentation. Aliases are legacy
+// environment variables that are still honored.
+func Bool(instrumentation, name string, def bool, description string, aliases ...string) *BoolOption {
+	key := Key(instrumentation, name)
+	return register(key, func() *BoolOption {
+		return &BoolOption{
+			baseOption: newBaseOption(instrumentation, name, "bool", strconv.FormatBool(def), description, aliases),
+			defValue:   def,
+		}
+	})
+}
+
+func (o *BoolOption) resolve() bool {
+	v := o.defValue
+	if raw, ok := o.lookupRaw(); ok {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			o.invalid()
+		} else {
+			v = parsed
+		}
+	}
+	return o.value.Swap(v) != v
+}
+
+func (o *BoolOption) Get() bool {
+	return o.value.Load()
+}
+
+func (o *BoolOption) setting() Setting {
+	return o.toSetting(strconv.FormatBool(o.Get()))
+}
+
+// StringOption is a string instrumentation setting.
+type StringOption struct {
+	*baseOption
+	value atomic.Pointer[string]
+}
+
+// String registers a string option of the instrumentation.
+func String(instrumentation, name string, def string, description string, aliases ...string) *StringOption {
+	key := Key(instrumentation, name)
+	return register(key, func() *StringOption {
+		return &StringOption{
+			baseOption: newBaseOption(instrumentation, name, "string", def, description, aliases),
+		}
+	})
+}
+
+func (o *StringOption) resolve() bool {
+	v := o.def
+	if raw, ok := o.lookupRaw(); ok {
+		v = strings.TrimSpace(raw)
+	}
+	old := o.value.Swap(&v)
+	return old == nil || *old != v
+}
+
+func (o *StringOption) Get() string {
+	if v := o.value.Load(); v != nil {
+		return *v
+	}
+	return o.def
+}
+
+func (o *StringOption) setting() Setting {
+	return o.toSetting(o.Get())
+}
+
+// StringSliceOption is a comma-separated list instrumentation setting, empty
+// elements are dropped. The returned slice is shared and must not be modified.
+type StringSliceOption struct {
+	*baseOption
+	value atomic.Pointer[[]string]
+}
+
+// StringSlice registers a comma-separated list option of the instrumentation.
+func StringSlice(instrumentation, name string, def []string, description string, aliases ...string) *StringSliceOption {
+	key := Key(instrumentation, name)
+	return register(key, func() *StringSliceOption {
+		return &StringSliceOption{
+			baseOption: newBaseOption(instrumentation, name, "list", strings.Join(def, ","), description, aliases),
+		}
+	})
+}
+
+func (o *StringSliceOption) resolve() bool {
+	v := splitList(o.def)
+	if raw, ok := o.lookupRaw(); ok {
+		v = splitList(raw)
+	}
+	old := o.value.Swap(&v)
+	return old == nil || !slices.Equal(*old, v)
+}
+
+func (o *StringSliceOption) Get() []string {
+	if v := o.value.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (o *StringSliceOption) setting() Setting {
+	return o.toSetting(strings.Join(o.Get(), ","))
+}
+
+func splitList(raw string) []string {
+	parts := strings.Split(raw, ",")
+	list := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// IntOption is an integer instrumentation setting.
+type IntOption struct {
+	*baseOption
+	defValue int
+	value    atomic.Int64
+}
+
+// Int registers an integer option of the instrumentation.
+func Int(instrumentation, name string, def int, description string, aliases ...string) *IntOption {
+	key := Key(instrumentation, name)
+	return register(key, func() *IntOption {
+		return &IntOption{
+			baseOption: newBaseOption(instrumentation, name, "int", strconv.Itoa(def), description, aliases),
+			defValue:   def,
+		}
+	})
+}
+
+func (o *IntOption) resolve() bool {
+	v := o.defValue
+	if raw, ok := o.lookupRaw(); ok {
+		parsed, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			o.invalid()
+		} else {
+			v = parsed
+		}
+	}
+	return o.value.Swap(int64(v)) != int64(v)
+}
+
+func (o *IntOption) Get() int {
+	return int(o.value.Load())
+}
+
+func (o *IntOption) setting() Setting {
+	return o.toSetting(strconv.Itoa(o.Get()))
+}
+
+// FloatOption is a floating point instrumentation setting.
+type FloatOption struct {
+	*baseOption
+	defValue float64
+	value    atomic.Uint64
+}
+
+// Float registers a floating point option of the instrumentation.
+func Float(instrumentation, name string, def float64, description string, aliases ...string) *FloatOption {
+	key := Key(instrumentation, name)
+	return register(key, func() *FloatOption {
+		return &FloatOption{
+			baseOption: newBaseOption(instrumentation, name, "float", strconv.FormatFloat(def, 'f', -1, 64), description, aliases),
+			defValue:   def,
+		}
+	})
+}
+
+func (o *FloatOption) resolve() bool {
+	v := o.defValue
+	if raw, ok := o.lookupRaw(); ok {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			o.invalid()
+		} else {
+			v = parsed
+		}
+	}
+	bits := math.Float64bits(v)
+	return o.value.Swap(bits) != bits
+}
+
+func (o *FloatOption) Get() float64 {
+	return math.Float64frombits(o.value.Load())
+}
+
+func (o *FloatOption) setting() Setting {
+	return o.toSetting(strconv.FormatFloat(o.Get(), 'f', -1, 64))
+}
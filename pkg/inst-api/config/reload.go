@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// EnvConfigWatchInterval is the interval to check the config file for
+	// changes, e.g. "10s". Zero or a negative value disables the watching.
+	EnvConfigWatchInterval = "OTEL_INSTRUMENTATION_CONFIG_WATCH_INTERVAL"
+	// EnvConfigReloadOnSighup reloads the config file when the process
+	// receives SIGHUP if it is set to true.
+	EnvConfigReloadOnSighup = "OTEL_INSTRUMENTATION_CONFIG_RELOAD_ON_SIGHUP"
+
+	defaultWatchInterval = 10 * time.Second
+)
+
+var (
+	reloadLock      sync.Mutex
+	listenersLock   sync.Mutex
+	reloadListeners []func()
+)
+
+// OnReload registers a listener that is called after a reload changed any
+// option. Components deriving state from options, e.g. compiled patterns,
+// can use it to rebuild the state.
+func OnReload(listener func()) {
+	listenersLock.Lock()
+	defer listenersLock.Unlock()
+	reloadListeners = append(reloadListeners, listener)
+}
+
+// Reload reads the config file again and re-resolves all registered options.
+// Options take new values atomically, so new operations observe them
+// immediately while in-flight ones are not affected. If the config file can
+// not be loaded, the previous values are kept.
+func Reload() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	values := make(map[string]string)
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		loaded, err := loadConfigFile(path)
+		if err != nil {
+			return err
+		}
+		values = loaded
+	}
+	// the file is loaded, don't let a lazy lookup overwrite it
+	fileLoadOnce.Do(func() {})
+	fileValues.Store(&values)
+
+	registryLock.Lock()
+	options := make([]option, 0, len(registry))
+	for _, o := range registry {
+		options = append(options, o)
+	}
+	registryLock.Unlock()
+	changed := false
+	for _, o := range options {
+		if o.resolve() {
+			s := o.setting()
+			log.Printf("instrumentation config %s changed to %q", s.Key, s.Value)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	listenersLock.Lock()
+	listeners := append([]func(){}, reloadListeners...)
+	listenersLock.Unlock()
+	for _, listener := range listeners {
+		listener()
+	}
+	return nil
+}
+
+// Watch polls the config file every interval and reloads it when its size or
+// modification time changes. The returned function stops watching and waits
+// for an ongoing reload to finish.
+func Watch(path string, interval time.Duration) (stop func()) {
+	done, exited := make(chan struct{}), make(chan struct{})
+	last, _ := os.Stat(path)
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(path)
+			if err != nil || !fileChanged(last, info) {
+				continue
+			}
+			last = info
+			if err = Reload(); err != nil {
+				log.Printf("failed to reload instrumentation config file %s: %v", path, err)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
+
+func fileChanged(last, current os.FileInfo) bool {
+	if last == nil {
+		return true
+	}
+	return !last.ModTime().Equal(current.ModTime()) || last.Size() != current.Size()
+}
+
+// ReloadOnSignal reloads the config whenever the process receives one of the
+// signals. The returned function stops listening and waits for an ongoing
+// reload to finish.
+func ReloadOnSignal(sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ch:
+				if err := Reload(); err != nil {
+					log.Printf("failed to reload instrumentation config: %v", err)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+		<-exited
+	}
+}
+
+// StartReloading enables the runtime reloading configured by the environment:
+// the config file is watched every OTEL_INSTRUMENTATION_CONFIG_WATCH_INTERVAL
+// (10s by default) and, if OTEL_INSTRUMENTATION_CONFIG_RELOAD_ON_SIGHUP is
+// true, reloaded on SIGHUP.
+func StartReloading() {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		interval := defaultWatchInterval
+		if v := os.Getenv(EnvConfigWatchInterval); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				log.Printf("invalid duration %q for %s, fallback to %s", v, EnvConfigWatchInterval, defaultWatchInterval)
+			} else {
+				interval = d
+			}
+		}
+		if interval > 0 {
+			Watch(path, interval)
+		}
+	}
+	if os.Getenv(EnvConfigReloadOnSighup) == "true" {
+		ReloadOnSignal(syscall.SIGHUP)
+	}
+}
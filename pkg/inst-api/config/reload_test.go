@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReload(t *testing.T) {
+	resetConfigFile(t, "otel.instrumentation.test-reload.enabled=true\n")
+	enabler := NewInstrumentationEnabler("test-reload")
+	ratio := Float("test-reload", "ratio", 1, "")
+	if !enabler.Enable() || ratio.Get() != 1 {
+		t.Fatal("unexpected initial values")
+	}
+	var notified atomic.Int32
+	OnReload(func() { notified.Add(1) })
+
+	path := os.Getenv(EnvConfigFile)
+	content := "otel.instrumentation.test-reload.enabled=false\n" +
+		"otel.instrumentation.test-reload.ratio=0.5\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if enabler.Enable() {
+		t.Error("instrumentation should be disabled after reload")
+	}
+	if ratio.Get() != 0.5 {
+		t.Errorf("ratio = %f; expected 0.5", ratio.Get())
+	}
+	if notified.Load() != 1 {
+		t.Errorf("listener notified %d times; expected 1", notified.Load())
+	}
+	// nothing changed, listeners are not notified again
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if notified.Load() != 1 {
+		t.Errorf("listener notified %d times; expected 1", notified.Load())
+	}
+	// a broken file keeps the previous values
+	if err := os.WriteFile(path, []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err == nil {
+		t.Error("expected error for malformed config file")
+	}
+	if enabler.Enable() {
+		t.Error("previous values should be kept")
+	}
+}
+
+func TestReloadConcurrentAccess(t *testing.T) {
+	resetConfigFile(t, "otel.instrumentation.test-concurrent.list=a,b\n")
+	list := StringSlice("test-concurrent", "list", nil, "")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if n := len(list.Get()); n != 2 {
+					t.Errorf("unexpected list %v", list.Get())
+					return
+				}
+				_ = Settings()
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := Reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestWatch(t *testing.T) {
+	resetConfigFile(t, "otel.instrumentation.test-watch.enabled=true\n")
+	enabler := NewInstrumentationEnabler("test-watch")
+	path := os.Getenv(EnvConfigFile)
+	stop := Watch(path, 10*time.Millisecond)
+	defer stop()
+
+	content := "otel.instrumentation.test-watch.enabled=false\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for enabler.Enable() {
+		if time.Now().After(deadline) {
+			t.Fatal("the change of the config file is not picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -43,6 +44,7 @@ type InternalInstrumenter[REQUEST any, RESPONSE any] struct {
 	operationListeners   []OperationListener
 	contextCustomizers   []ContextCustomizer[REQUEST]
 	spanSuppressor       SpanSuppressor
+	spanNameFilter       utils.SpanNameFilter
 	tracer               trace.Tracer
 	instVersion          string
 }
@@ -66,6 +68,24 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) ShouldStart(parentContext cont
 	return !suppressed
 }
 
+// startedKey records in the context whether doStart of the instrumenter inst
+// started a span, so that doEnd stays consistent with it even if the enabler
+// is toggled in between. It is keyed by the instrumenter as the contexts of
+// nested instrumenters are derived from each other.
+type startedKey struct {
+	inst any
+}
+
+// skipStart returns the context of a doStart that started no span. The parent
+// context is returned as is unless it holds a span started by i, which doEnd
+// must not end.
+func (i *InternalInstrumenter[REQUEST, RESPONSE]) skipStart(parentContext context.Context) context.Context {
+	if started, _ := parentContext.Value(startedKey{inst: i}).(bool); !started {
+		return parentContext
+	}
+	return context.WithValue(parentContext, startedKey{inst: i}, false)
+}
+
 var cachePool = &sync.Pool{
 	New: func() interface{} {
 		return make([]attribute.KeyValue, 0, 25)
@@ -97,13 +117,16 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) Start(parentContext context.Co
 
 func (i *InternalInstrumenter[REQUEST, RESPONSE]) doStart(parentContext context.Context, request REQUEST, timestamp time.Time, options ...trace.SpanStartOption) context.Context {
 	if i.enabler != nil && !i.enabler.Enable() {
-		return parentContext
+		return i.skipStart(parentContext)
+	}
+	// extract span name
+	spanName := i.spanNameExtractor.Extract(request)
+	if i.spanNameFilter != nil && i.spanNameFilter.FilterSpanName(spanName) {
+		return i.skipStart(parentContext)
 	}
 	for _, listener := range i.operationListeners {
 		parentContext = listener.OnBeforeStart(parentContext, timestamp)
 	}
-	// extract span name
-	spanName := i.spanNameExtractor.Extract(request)
 	spanKind := i.spanKindExtractor.Extract(request)
 	options = append(options, trace.WithSpanKind(spanKind), trace.WithTimestamp(timestamp))
 	newCtx, span := i.tracer.Start(parentContext, spanName, options...)
@@ -120,6 +143,7 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) doStart(parentContext context.
 		newCtx = listener.OnBeforeEnd(newCtx, attrs, timestamp)
 	}
 	span.SetAttributes(attrs...)
+	newCtx = context.WithValue(newCtx, startedKey{inst: i}, true)
 	return i.spanSuppressor.StoreInContext(newCtx, spanKind, span)
 }
 
@@ -128,7 +152,7 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) End(ctx context.Context, reque
 }
 
 func (i *InternalInstrumenter[REQUEST, RESPONSE]) doEnd(ctx context.Context, request REQUEST, response RESPONSE, err error, timestamp time.Time, options ...trace.SpanEndOption) {
-	if i.enabler != nil && !i.enabler.Enable() {
+	if started, _ := ctx.Value(startedKey{inst: i}).(bool); !started {
 		return
 	}
 	for _, listener := range i.operationListeners {
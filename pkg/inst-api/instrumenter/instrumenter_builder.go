@@ -15,6 +15,7 @@
 package instrumenter
 
 import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
@@ -48,6 +49,7 @@ type Builder[REQUEST any, RESPONSE any] struct {
 	OperationListeners   []OperationListener
 	ContextCustomizers   []ContextCustomizer[REQUEST]
 	SpanSuppressor       SpanSuppressor
+	SpanNameFilter       utils.SpanNameFilter
 	InstVersion          string
 	Scope                instrumentation.Scope
 }
@@ -75,6 +77,13 @@ func (b *Builder[REQUEST, RESPONSE]) SetSpanNameExtractor(spanNameExtractor Span
 	return b
 }
 
+// SetSpanNameFilter skips the span whose name is filtered, the context is
+// still propagated as if the instrumentation is disabled.
+func (b *Builder[REQUEST, RESPONSE]) SetSpanNameFilter(spanNameFilter utils.SpanNameFilter) *Builder[REQUEST, RESPONSE] {
+	b.SpanNameFilter = spanNameFilter
+	return b
+}
+
 func (b *Builder[REQUEST, RESPONSE]) SetSpanStatusExtractor(spanStatusExtractor SpanStatusExtractor[REQUEST, RESPONSE]) *Builder[REQUEST, RESPONSE] {
 	b.SpanStatusExtractor = spanStatusExtractor
 	return b
@@ -114,6 +123,7 @@ func (b *Builder[REQUEST, RESPONSE]) BuildInstrumenter() *InternalInstrumenter[R
 		operationListeners:   b.OperationListeners,
 		contextCustomizers:   b.ContextCustomizers,
 		spanSuppressor:       b.buildSpanSuppressor(),
+		spanNameFilter:       b.SpanNameFilter,
 		tracer:               tracer,
 		instVersion:          b.InstVersion,
 	}
@@ -129,6 +139,7 @@ func (b *Builder[REQUEST, RESPONSE]) BuildInstrumenterWithTracer(tracer trace.Tr
 		operationListeners:   b.OperationListeners,
 		contextCustomizers:   b.ContextCustomizers,
 		spanSuppressor:       b.buildSpanSuppressor(),
+		spanNameFilter:       b.SpanNameFilter,
 		tracer:               tracer,
 		instVersion:          b.InstVersion,
 	}
@@ -149,6 +160,7 @@ func (b *Builder[REQUEST, RESPONSE]) BuildPropagatingToDownstreamInstrumenter(ca
 			operationListeners:   b.OperationListeners,
 			contextCustomizers:   b.ContextCustomizers,
 			spanSuppressor:       b.buildSpanSuppressor(),
+			spanNameFilter:       b.SpanNameFilter,
 			tracer:               tracer,
 			instVersion:          b.InstVersion,
 		},
@@ -171,6 +183,7 @@ func (b *Builder[REQUEST, RESPONSE]) BuildPropagatingFromUpstreamInstrumenter(ca
 			attributesExtractors: b.AttributesExtractors,
 			operationListeners:   b.OperationListeners,
 			spanSuppressor:       b.buildSpanSuppressor(),
+			spanNameFilter:       b.SpanNameFilter,
 			tracer:               tracer,
 			instVersion:          b.InstVersion,
 		},
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +56,21 @@ func (d disableEnabler) Enable() bool {
 	return false
 }
 
+type toggleEnabler struct {
+	enabled bool
+}
+
+func (e *toggleEnabler) Enable() bool {
+	return e.enabled
+}
+
+type testSpanNameFilter struct {
+}
+
+func (f testSpanNameFilter) FilterSpanName(spanName string) bool {
+	return spanName == "test"
+}
+
 type mockProp struct {
 	val string
 }
@@ -194,6 +210,152 @@ func TestEnabler(t *testing.T) {
 	}
 }
 
+func TestEnablerToggledBetweenStartAndEnd(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sr),
+	)
+	enabler := &toggleEnabler{enabled: true}
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		SetInstrumentEnabler(enabler)
+	instrumenter := builder.BuildInstrumenterWithTracer(tp.Tracer("test-tracer"))
+
+	// a span started while enabled must still be ended after disabling
+	ctx := instrumenter.Start(context.Background(), testRequest{})
+	enabler.enabled = false
+	instrumenter.End(ctx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 1 {
+		t.Fatalf("expected 1 ended span, got %d", len(sr.Ended()))
+	}
+
+	// a skipped start must not end the parent span after enabling
+	parentCtx, parent := tp.Tracer("test-tracer").Start(context.Background(), "parent")
+	ctx = instrumenter.Start(parentCtx, testRequest{})
+	enabler.enabled = true
+	instrumenter.End(ctx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 1 {
+		t.Fatalf("the parent span should not be ended, got %d ended spans", len(sr.Ended()))
+	}
+	parent.End()
+}
+
+func TestNestedInstrumenterSkipped(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sr),
+	)
+	build := func(configure func(builder *Builder[testRequest, testResponse])) Instrumenter[testRequest, testResponse] {
+		builder := Builder[testRequest, testResponse]{}
+		builder.Init().
+			SetSpanNameExtractor(testNameExtractor{}).
+			SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{})
+		configure(&builder)
+		return builder.BuildInstrumenterWithTracer(tp.Tracer("test-tracer"))
+	}
+	enabled := func(builder *Builder[testRequest, testResponse]) {}
+	filtered := func(builder *Builder[testRequest, testResponse]) {
+		builder.SetSpanNameFilter(testSpanNameFilter{})
+	}
+	disabled := func(builder *Builder[testRequest, testResponse]) {
+		builder.SetInstrumentEnabler(disableEnabler{})
+	}
+
+	// the outer span is ended even if it is ended with the context of a
+	// nested instrumenter that skipped its span
+	outer, inner := build(enabled), build(filtered)
+	ctx := outer.Start(context.Background(), testRequest{})
+	innerCtx := inner.Start(ctx, testRequest{})
+	inner.End(innerCtx, testRequest{}, testResponse{}, nil)
+	outer.End(innerCtx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 1 {
+		t.Fatalf("expected the outer span to be ended, got %d ended spans", len(sr.Ended()))
+	}
+
+	// a skipped outer span does not end the span of a nested instrumenter
+	outer, inner = build(disabled), build(enabled)
+	ctx = outer.Start(context.Background(), testRequest{})
+	innerCtx = inner.Start(ctx, testRequest{})
+	outer.End(innerCtx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 1 {
+		t.Fatalf("the inner span should not be ended by the outer one, got %d ended spans", len(sr.Ended()))
+	}
+	inner.End(innerCtx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 2 {
+		t.Fatalf("expected the inner span to be ended, got %d ended spans", len(sr.Ended()))
+	}
+}
+
+func TestSkippedStartKeepsParentContext(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sr),
+	)
+	enabler := &toggleEnabler{enabled: false}
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		SetInstrumentEnabler(enabler)
+	instrumenter := builder.BuildInstrumenterWithTracer(tp.Tracer("test-tracer"))
+
+	// nothing is derived from the parent context when no span is started
+	parentCtx, parent := tp.Tracer("test-tracer").Start(context.Background(), "parent")
+	if ctx := instrumenter.Start(parentCtx, testRequest{}); ctx != parentCtx {
+		t.Fatalf("expected the parent context to be returned as is")
+	}
+	parent.End()
+
+	// a skipped start nested in a span of the same instrumenter does not end it
+	enabler.enabled = true
+	outerCtx := instrumenter.Start(context.Background(), testRequest{})
+	enabler.enabled = false
+	innerCtx := instrumenter.Start(outerCtx, testRequest{})
+	enabler.enabled = true
+	instrumenter.End(innerCtx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 1 {
+		t.Fatalf("the outer span should not be ended, got %d ended spans", len(sr.Ended()))
+	}
+	instrumenter.End(outerCtx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 2 {
+		t.Fatalf("expected the outer span to be ended, got %d ended spans", len(sr.Ended()))
+	}
+}
+
+func TestSpanNameFilter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sr),
+	)
+	originalTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(originalTP)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		SetSpanNameFilter(testSpanNameFilter{})
+	carrier := propagation.MapCarrier{}
+	instrumenter := builder.BuildPropagatingToDownstreamInstrumenter(func(request testRequest) propagation.TextMapCarrier {
+		return carrier
+	}, nil)
+	parentCtx, parent := tp.Tracer("test-tracer").Start(context.Background(), "parent")
+	ctx := instrumenter.Start(parentCtx, testRequest{})
+	instrumenter.End(ctx, testRequest{}, testResponse{}, nil)
+	if len(sr.Ended()) != 0 {
+		t.Fatalf("the filtered span should not be recorded, got %d ended spans", len(sr.Ended()))
+	}
+	// the context is still propagated to the downstream
+	if !strings.Contains(carrier.Get("traceparent"), parent.SpanContext().TraceID().String()) {
+		t.Errorf("expected the parent context to be injected, got %v", carrier)
+	}
+	parent.End()
+}
+
 func TestPropFromUpStream(t *testing.T) {
 	builder := Builder[testRequest, testResponse]{}
 	builder.Init().
@@ -49,6 +49,13 @@ type SpanKindSuppressor struct {
 	delegates map[trace.SpanKind]SpanSuppressor
 }
 
+// NewSpanSuppressor returns the suppressor of the configured strategy for
+// spans with the given keys. It is meant for rules that guard spans which are
+// not started by an Instrumenter, e.g. the ones of a framework's own tracing.
+func NewSpanSuppressor(spanKeys ...attribute.Key) SpanSuppressor {
+	return getSpanSuppressionStrategyFromEnv().create(spanKeys)
+}
+
 func getSpanSuppressionStrategyFromEnv() SpanSuppressorStrategy {
 	suppressionStrategy := os.Getenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY")
 	switch suppressionStrategy {
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"reflect"
 	"testing"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
@@ -117,3 +118,23 @@ func TestSpanKindSuppressor(t *testing.T) {
 		t.Errorf("should not suppress span with different span key")
 	}
 }
+
+func TestNewSpanSuppressor(t *testing.T) {
+	tests := []struct {
+		strategy string
+		keys     []attribute.Key
+		expected SpanSuppressor
+	}{
+		{"", []attribute.Key{utils.HTTP_SERVER_KEY}, &SpanKeySuppressor{}},
+		{"", nil, &NoopSpanSuppressor{}},
+		{"none", []attribute.Key{utils.HTTP_SERVER_KEY}, &NoopSpanSuppressor{}},
+		{"span-kind", []attribute.Key{utils.HTTP_SERVER_KEY}, &SpanKindSuppressor{}},
+	}
+	for _, test := range tests {
+		t.Setenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY", test.strategy)
+		s := NewSpanSuppressor(test.keys...)
+		if reflect.TypeOf(s) != reflect.TypeOf(test.expected) {
+			t.Errorf("strategy %q: expect %T, got %T", test.strategy, test.expected, s)
+		}
+	}
+}
@@ -14,7 +14,16 @@
 
 package utils
 
-import "net/url"
+import (
+	"log"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+)
 
 type UrlFilter interface {
 	FilterUrl(url *url.URL) bool
@@ -30,3 +39,139 @@ type DefaultUrlFilter struct {
 func (d DefaultUrlFilter) FilterUrl(url *url.URL) bool {
 	return false
 }
+
+// regexPrefix marks a pattern as a regular expression, other patterns are
+// globs where "*" matches any characters except "/", "**" matches any
+// characters and "?" matches a single character except "/".
+const regexPrefix = "regex:"
+
+// HttpFilter excludes HTTP requests whose path, host, method or span name
+// matches any of the configured patterns. Patterns are read from
+// otel.instrumentation.http.<kind>.exclude.{paths,hosts,methods,span-names}
+// and recompiled when the config is reloaded. Hosts and methods are matched
+// case-insensitively, the port of the host is ignored.
+type HttpFilter struct {
+	paths     *config.StringSliceOption
+	hosts     *config.StringSliceOption
+	methods   *config.StringSliceOption
+	spanNames *config.StringSliceOption
+	compiled  atomic.Pointer[httpFilterPatterns]
+}
+
+type httpFilterPatterns struct {
+	paths     []*regexp.Regexp
+	hosts     []*regexp.Regexp
+	methods   []*regexp.Regexp
+	spanNames []*regexp.Regexp
+}
+
+// NewHttpServerFilter returns the filter consulted by HTTP server
+// instrumentations.
+func NewHttpServerFilter() *HttpFilter {
+	return newHttpFilter("server")
+}
+
+// NewHttpClientFilter returns the filter consulted by HTTP client
+// instrumentations.
+func NewHttpClientFilter() *HttpFilter {
+	return newHttpFilter("client")
+}
+
+func newHttpFilter(kind string) *HttpFilter {
+	f := &HttpFilter{
+		paths:     config.StringSlice("http", kind+".exclude.paths", nil, "URL paths of HTTP "+kind+" requests that are not traced."),
+		hosts:     config.StringSlice("http", kind+".exclude.hosts", nil, "Hosts of HTTP "+kind+" requests that are not traced."),
+		methods:   config.StringSlice("http", kind+".exclude.methods", nil, "Methods of HTTP "+kind+" requests that are not traced."),
+		spanNames: config.StringSlice("http", kind+".exclude.span-names", nil, "Span names of HTTP "+kind+" requests that are not traced."),
+	}
+	f.compile()
+	config.OnReload(f.compile)
+	return f
+}
+
+func (f *HttpFilter) compile() {
+	f.compiled.Store(&httpFilterPatterns{
+		paths:     compilePatterns(f.paths.Get(), false),
+		hosts:     compilePatterns(f.hosts.Get(), true),
+		methods:   compilePatterns(f.methods.Get(), true),
+		spanNames: compilePatterns(f.spanNames.Get(), false),
+	})
+}
+
+func (f *HttpFilter) FilterUrl(u *url.URL) bool {
+	if u == nil {
+		return false
+	}
+	return f.FilterRequest("", u.Host, u.Path)
+}
+
+// FilterRequest reports whether the request should not be traced, empty
+// values are not matched.
+func (f *HttpFilter) FilterRequest(method, host, path string) bool {
+	p := f.compiled.Load()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return matchAny(p.paths, path) || matchAny(p.hosts, host) || matchAny(p.methods, method)
+}
+
+func (f *HttpFilter) FilterSpanName(spanName string) bool {
+	return matchAny(f.compiled.Load().spanNames, spanName)
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePatterns(patterns []string, ignoreCase bool) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		var expr string
+		if strings.HasPrefix(pattern, regexPrefix) {
+			expr = "^(?:" + strings.TrimPrefix(pattern, regexPrefix) + ")$"
+		} else {
+			expr = globToRegex(pattern)
+		}
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			log.Printf("invalid http filter pattern %q: %v", pattern, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -17,6 +17,8 @@ package utils
 import (
 	"net/url"
 	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 )
 
 func TestDefaultUrlFilter(t *testing.T) {
@@ -39,3 +41,51 @@ func TestDefaultUrlFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpFilter(t *testing.T) {
+	t.Cleanup(func() { _ = config.Reload() })
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_PATHS", "/healthz,/static/**,regex:/api/v[0-9]+/ping")
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_HOSTS", "*.internal")
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_METHODS", "options")
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_SPAN_NAMES", "GET /metrics")
+	filter := NewHttpServerFilter()
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		method   string
+		host     string
+		path     string
+		expected bool
+	}{
+		{"GET", "example.com", "/healthz", true},
+		{"GET", "example.com", "/healthz/deep", false},
+		{"GET", "example.com", "/static/css/app.css", true},
+		{"GET", "example.com", "/api/v2/ping", true},
+		{"GET", "example.com", "/api/vx/ping", false},
+		{"GET", "svc.internal:8080", "/users", true},
+		{"OPTIONS", "example.com", "/users", true},
+		{"GET", "example.com", "/users", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.host+tc.path, func(t *testing.T) {
+			if result := filter.FilterRequest(tc.method, tc.host, tc.path); result != tc.expected {
+				t.Errorf("FilterRequest() = %v; expected %v", result, tc.expected)
+			}
+		})
+	}
+	if !filter.FilterUrl(&url.URL{Host: "example.com", Path: "/healthz"}) {
+		t.Error("expected the url to be filtered")
+	}
+	if !filter.FilterSpanName("GET /metrics") || filter.FilterSpanName("GET /users") {
+		t.Error("unexpected span name filtering")
+	}
+	// patterns are recompiled after a reload
+	t.Setenv("OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_PATHS", "/readyz")
+	if err := config.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if filter.FilterRequest("GET", "example.com", "/healthz") || !filter.FilterRequest("GET", "example.com", "/readyz") {
+		t.Error("the reloaded patterns are not applied")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// InstrumentsOnce creates the instruments of a metric exactly once, as soon as
+// the meter is available. The instruments may only be read after Do or Create
+// returned.
+type InstrumentsOnce struct {
+	once  sync.Once
+	ready atomic.Bool
+}
+
+// Do creates the instruments with the meter returned by meter unless they
+// were created already, and reports whether they can be read. Nothing is
+// created while there is no meter yet, Do is expected to be called again then.
+func (o *InstrumentsOnce) Do(meter func() metric.Meter, create func(meter metric.Meter)) bool {
+	if o.ready.Load() {
+		return true
+	}
+	m := meter()
+	if m == nil {
+		return false
+	}
+	o.once.Do(func() {
+		create(m)
+		o.ready.Store(true)
+	})
+	return true
+}
+
+// Create creates the instruments right away, e.g. with a meter given by a
+// test, and returns the error of create.
+func (o *InstrumentsOnce) Create(create func() error) error {
+	var err error
+	o.once.Do(func() {
+		err = create()
+		o.ready.Store(true)
+	})
+	return err
+}
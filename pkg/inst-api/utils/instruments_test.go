@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestInstrumentsOnceWaitsForMeter(t *testing.T) {
+	var o InstrumentsOnce
+	var meter metric.Meter
+	created := 0
+	create := func(metric.Meter) { created++ }
+	if o.Do(func() metric.Meter { return meter }, create) {
+		t.Fatal("expect no instruments without a meter")
+	}
+	meter = noop.NewMeterProvider().Meter("test")
+	for i := 0; i < 2; i++ {
+		if !o.Do(func() metric.Meter { return meter }, create) {
+			t.Fatal("expect instruments with a meter")
+		}
+	}
+	if created != 1 {
+		t.Fatalf("instruments created %d times; expected once", created)
+	}
+}
+
+func TestInstrumentsOnceCreate(t *testing.T) {
+	var o InstrumentsOnce
+	if err := o.Create(func() error { return errors.New("nil meter") }); err == nil {
+		t.Fatal("expect the error of create")
+	}
+	if !o.Do(func() metric.Meter { return nil }, func(metric.Meter) { t.Fatal("expect no second creation") }) {
+		t.Fatal("expect instruments after Create")
+	}
+}
@@ -35,6 +35,7 @@ const NET_HTTP_SERVER_SCOPE_NAME = "pkg/rules/http/server_setup.go"
 const KRATOS_GRPC_INTERNAL_SCOPE_NAME = "pkg/rules/kratos/grpc/kratos_internal_setup.go"
 const KRATOS_HTTP_INTERNAL_SCOPE_NAME = "pkg/rules/kratos/http/kratos_internal_setup.go"
 const MONGO_SCOPE_NAME = "pkg/rules/mongo/client_setup.go"
+const MONGO_V2_SCOPE_NAME = "pkg/rules/mongov2/client_setup.go"
 const REDIGO_SCOPE_NAME = "pkg/rules/redigo/redigo_client_setup.go"
 const ELASTICSEARCH_SCOPE_NAME = "pkg/rules/elasticsearch/es_client_setup.go"
 const GOMICRO_CLIENT_SCOPE_NAME = "pkg/rules/gomicro/client/gomicro_client_setup.go"
@@ -47,7 +48,17 @@ const MCP_SCOPE_NAME = "pkg/rules/mcp/setup.go"
 const K8S_CLIENT_GO_SCOPE_NAME = "pkg/rules/k8s-client-go/setup.go"
 const KAFKAGO_PRODUCER_SCOPE_NAME = "pkg/rules/segmentio-kafka-go/kafka_producer_setup.go"
 const KAFKAGO_CONSUMER_SCOPE_NAME = "pkg/rules/segmentio-kafka-go/kafka_consumer_setup.go"
+const SARAMA_PRODUCER_SCOPE_NAME = "pkg/rules/sarama/sarama_producer_setup.go"
+const SARAMA_CONSUMER_SCOPE_NAME = "pkg/rules/sarama/sarama_consumer_setup.go"
+const FRANZGO_PRODUCER_SCOPE_NAME = "pkg/rules/franz-go/franz_producer_setup.go"
+const FRANZGO_CONSUMER_SCOPE_NAME = "pkg/rules/franz-go/franz_consumer_setup.go"
+const NATS_PUBLISH_SCOPE_NAME = "pkg/rules/nats/nats_publish_setup.go"
+const NATS_CONSUME_SCOPE_NAME = "pkg/rules/nats/nats_consume_setup.go"
 const GOPG_SCOPE_NAME = "pkg/rules/gopg/setup.go"
 const SENTINEL_SCOPE_NAME = "pkg/rules/sentinel/setup.go"
 const GOCQL_SCOPE_NAME = "pkg/rules/gocql/setup.go"
 const SQLX_SCOPE_NAME = "pkg/rules/sqlx/setup.go"
+const PGX_SCOPE_NAME = "pkg/rules/pgx/setup.go"
+const OS_EXEC_SCOPE_NAME = "pkg/rules/exec/setup.go"
+const CONNECT_CLIENT_SCOPE_NAME = "pkg/rules/connect/connect_client_setup.go"
+const CONNECT_SERVER_SCOPE_NAME = "pkg/rules/connect/connect_server_setup.go"
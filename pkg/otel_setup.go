@@ -25,11 +25,15 @@ import (
 	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/core/meter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/core/sampler"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/experimental"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/process"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	testaccess "github.com/alibaba/loongsuite-go-agent/pkg/testaccess"
 	prometheus_client "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -37,7 +41,7 @@ import (
 
 	// The version of the following packages/modules must be fixed
 	"go.opentelemetry.io/otel"
-	_ "go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -53,6 +57,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // set the following environment variables based on https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables
@@ -94,9 +99,32 @@ func init() {
 	if strings.HasSuffix(path, exec_name) {
 		return
 	}
+	// list all known instrumentation settings and their current values
+	if os.Getenv(config.EnvConfigPrint) == "true" {
+		config.Dump(os.Stderr)
+	}
+	config.StartReloading()
 	if err = initOpenTelemetry(ctx); err != nil {
 		log.Fatalf("%s: %v", "Failed to initialize opentelemetry resource", err)
 	}
+	continueParentProcessTrace(ctx)
+}
+
+// continueParentProcessTrace extracts the TRACEPARENT, TRACESTATE and BAGGAGE
+// environment variables that the os/exec instrumentation of the parent process
+// injects. The baggage is saved to the GLS of the main goroutine, where the
+// goroutines it starts inherit it, while the span context becomes the parent
+// of the root spans.
+func continueParentProcessTrace(ctx context.Context) {
+	carrier := &process.EnvCarrier{Env: os.Environ()}
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		// ContextWithBaggage is instrumented to save the baggage to the GLS
+		baggage.ContextWithBaggage(ctx, bag)
+	}
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		trace.SetProcessParent(sc)
+	}
 }
 
 func newSpanProcessor(ctx context.Context) trace.SpanProcessor {
@@ -132,12 +160,15 @@ func initOpenTelemetry(ctx context.Context) error {
 
 	batchSpanProcessor = newSpanProcessor(ctx)
 
+	var opts []trace.TracerProviderOption
 	if batchSpanProcessor != nil {
-		traceProvider = trace.NewTracerProvider(
-			trace.WithSpanProcessor(batchSpanProcessor))
-	} else {
-		traceProvider = trace.NewTracerProvider()
+		opts = append(opts, trace.WithSpanProcessor(batchSpanProcessor))
+	}
+	// the sampling ratio can be changed at runtime by reloading the config
+	if s := sampler.FromEnv(); s != nil {
+		opts = append(opts, trace.WithSampler(s))
 	}
+	traceProvider = trace.NewTracerProvider(opts...)
 
 	otel.SetTracerProvider(traceProvider)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -200,6 +231,8 @@ func initMetrics() error {
 	db.InitDbMetrics(m)
 	// init ai metrics
 	ai.InitAIMetrics(m)
+	// init message metrics
+	message.InitMessageMetrics(m)
 	// nacos experimental metrics
 	experimental.InitNacosExperimentalMetrics(m)
 	// sentinel experimental metrics
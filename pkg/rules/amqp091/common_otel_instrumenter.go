@@ -15,6 +15,7 @@
 package amqp091
 
 import (
+	"fmt"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
@@ -74,7 +75,14 @@ func (RabbitMQGetter) GetBatchMessageCount(request RabbitRequest, response any)
 }
 
 func (RabbitMQGetter) GetMessageHeader(request RabbitRequest, name string) []string {
-	return []string{}
+	value, ok := request.headers[name]
+	if !ok || value == nil {
+		return []string{}
+	}
+	if v, ok := value.([]byte); ok {
+		return []string{string(v)}
+	}
+	return []string{fmt.Sprint(value)}
 }
 func (RabbitMQGetter) GetDestinationPartitionId(request RabbitRequest) string {
 	return ""
@@ -116,6 +124,7 @@ func BuildRabbitMQConsumeOtelInstrumenter() *instrumenter.PropagatingFromUpstrea
 	return builder.Init().SetSpanNameExtractor(&message.MessageSpanNameExtractor[RabbitRequest, any]{Getter: RabbitMQGetter{}, OperationName: message.RECEIVE}).
 		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[RabbitRequest]{}).
 		AddAttributesExtractor(&message.MessageAttrsExtractor[RabbitRequest, any, RabbitMQGetter]{Operation: message.RECEIVE}).
+		AddOperationListeners(message.MessageMetrics("amqp091.consume")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.AMQP091_SCOPE_NAME,
 			Version: version.Tag,
@@ -133,6 +142,7 @@ func BuildRabbitMQPublishOtelInstrumenter() *instrumenter.PropagatingToDownstrea
 			Version: version.Tag,
 		}).
 		AddAttributesExtractor(&message.MessageAttrsExtractor[RabbitRequest, any, RabbitMQGetter]{Operation: message.PUBLISH}).
+		AddOperationListeners(message.MessageMetrics("amqp091.publish")).
 		BuildPropagatingToDownstreamInstrumenter(func(n RabbitRequest) propagation.TextMapCarrier {
 			return &carrierGetter{req: n}
 		}, otel.GetTextMapPropagator())
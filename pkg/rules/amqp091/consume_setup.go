@@ -31,6 +31,9 @@ func consumeOnEnter(call api.CallContext,
 	tag string,
 	msg *amqp.Delivery,
 ) {
+	if !amqp091Enabler.Enable() {
+		return
+	}
 	request := RabbitRequest{
 		operationName:   "receive",
 		destinationName: msg.Exchange + ":" + msg.RoutingKey,
@@ -60,6 +63,9 @@ func consumeOnEnter(call api.CallContext,
 
 //go:linkname consumeOnExit github.com/rabbitmq/amqp091-go.consumeOnExit
 func consumeOnExit(call api.CallContext, b bool) {
+	if !amqp091Enabler.Enable() {
+		return
+	}
 	data, ok := call.GetData().(map[string]interface{})
 	if !ok {
 		return
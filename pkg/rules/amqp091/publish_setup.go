@@ -30,6 +30,9 @@ func publishWithDeferredConfirmOnEnter(call api.CallContext,
 	ch *amqp.Channel,
 	exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
 ) {
+	if !amqp091Enabler.Enable() {
+		return
+	}
 	request := RabbitRequest{
 		operationName:   "publish",
 		destinationName: exchange + ":" + key,
@@ -59,6 +62,9 @@ func publishWithDeferredConfirmOnEnter(call api.CallContext,
 
 //go:linkname publishWithDeferredConfirmOnExit github.com/rabbitmq/amqp091-go.publishWithDeferredConfirmOnExit
 func publishWithDeferredConfirmOnExit(call api.CallContext, confirm *amqp.DeferredConfirmation, err error) {
+	if !amqp091Enabler.Enable() {
+		return
+	}
 	data, ok := call.GetData().(map[string]interface{})
 	if !ok {
 		return
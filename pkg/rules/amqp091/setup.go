@@ -14,5 +14,9 @@
 
 package amqp091
 
+import "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+
+var amqp091Enabler = config.NewInstrumentationEnabler("amqp091")
+
 var RabbitMQConsumeInstrumenter = BuildRabbitMQConsumeOtelInstrumenter()
 var RabbitMQPublishInstrumenter = BuildRabbitMQPublishOtelInstrumenter()
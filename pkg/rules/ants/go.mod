@@ -0,0 +1,10 @@
+module github.com/alibaba/loongsuite-go-agent/pkg/rules/ants
+
+go 1.23.0
+
+replace github.com/alibaba/loongsuite-go-agent/pkg => ../../../pkg
+
+require (
+	github.com/alibaba/loongsuite-go-agent/pkg v0.0.0-00010101000000-000000000000
+	github.com/panjf2000/ants/v2 v2.4.2
+)
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ants
+
+import (
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/panjf2000/ants/v2"
+)
+
+// Pool workers are long-lived goroutines, so a task would run in the context
+// of whatever the worker ran before. Tasks are bound to the context of their
+// submitter instead.
+var antsEnabler = config.NewInstrumentationEnabler("ants")
+
+// invokeArg carries the submitter's context along with the argument of
+// PoolWithFunc.Invoke to the pool function.
+type invokeArg struct {
+	ctx *api.ContextSnapshot
+	arg interface{}
+}
+
+//go:linkname beforePoolSubmit github.com/panjf2000/ants/v2.beforePoolSubmit
+func beforePoolSubmit(call api.CallContext, p *ants.Pool, task func()) {
+	if !antsEnabler.Enable() {
+		return
+	}
+	call.SetParam(1, api.WrapTask(task))
+}
+
+//go:linkname beforeNewPoolWithFunc github.com/panjf2000/ants/v2.beforeNewPoolWithFunc
+func beforeNewPoolWithFunc(call api.CallContext, size int, pf func(interface{}), options ...ants.Option) {
+	if pf == nil {
+		return
+	}
+	// The pool function is wrapped unconditionally, Invoke may wrap the
+	// argument after the instrumentation is enabled at runtime
+	call.SetParam(1, func(arg interface{}) {
+		if a, ok := arg.(*invokeArg); ok {
+			a.ctx.Run(func() { pf(a.arg) })
+			return
+		}
+		pf(arg)
+	})
+}
+
+//go:linkname beforePoolWithFuncInvoke github.com/panjf2000/ants/v2.beforePoolWithFuncInvoke
+func beforePoolWithFuncInvoke(call api.CallContext, p *ants.PoolWithFunc, arg interface{}) {
+	if !antsEnabler.Enable() {
+		return
+	}
+	if ctx := api.CaptureContext(); ctx != nil {
+		call.SetParam(1, &invokeArg{ctx: ctx, arg: arg})
+	}
+}
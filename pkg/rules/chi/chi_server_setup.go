@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"net/http"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+var chiEnabler = config.NewInstrumentationEnabler("chi")
+
+// The route patterns are collected by routeHTTP, and by the one of every
+// mounted sub-router in turn, so the full pattern is only known on exit.
+//
+//go:linkname chiRouteOnEnter github.com/go-chi/chi/v5.chiRouteOnEnter
+func chiRouteOnEnter(call api.CallContext, mx *chi.Mux, w http.ResponseWriter, r *http.Request) {
+	if !chiEnabler.Enable() {
+		return
+	}
+	if r == nil || r.URL == nil {
+		return
+	}
+	call.SetData(r)
+}
+
+//go:linkname chiRouteOnExit github.com/go-chi/chi/v5.chiRouteOnExit
+func chiRouteOnExit(call api.CallContext) {
+	r, ok := call.GetData().(*http.Request)
+	if !ok {
+		return
+	}
+	lcs := trace.LocalRootSpanFromGLS()
+	if lcs == nil {
+		return
+	}
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return
+	}
+	tmpl := rctx.RoutePattern()
+	if tmpl != "" && tmpl != r.URL.Path {
+		lcs.SetName(tmpl)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var connectClientInstrumenter = BuildConnectClientInstrumenter()
+
+var connectServerInstrumenter = BuildConnectServerInstrumenter()
+
+// connectServerSuppressor detects the span of the net/http server a handler is
+// served by
+var connectServerSuppressor = instrumenter.NewSpanSuppressor(utils.HTTP_SERVER_KEY)
+
+// connectInterceptor is put in front of the interceptors of every client and
+// handler, so that its span covers the whole call.
+type connectInterceptor struct {
+}
+
+func (i *connectInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !connectEnabler.Enable() {
+			return next(ctx, req)
+		}
+		if req.Spec().IsClient {
+			request := connectRequest{
+				procedure:     req.Spec().Procedure,
+				serverAddress: req.Peer().Addr,
+				header:        req.Header(),
+			}
+			ctx = connectClientInstrumenter.Start(ctx, request)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.header))
+			resp, err := next(ctx, req)
+			connectClientInstrumenter.End(ctx, request, connectResponse{}, err)
+			return resp, err
+		}
+		request := connectRequest{
+			procedure:     req.Spec().Procedure,
+			serverAddress: localAddr(ctx),
+			header:        req.Header(),
+		}
+		if connectServerSuppressor.ShouldSuppress(ctx, trace.SpanKindServer) {
+			var resp connect.AnyResponse
+			err := serveInHttpSpan(ctx, request, func() (err error) {
+				resp, err = next(ctx, req)
+				return err
+			})
+			return resp, err
+		}
+		ctx = connectServerInstrumenter.Start(extract(ctx, request.header), request)
+		resp, err := next(ctx, req)
+		connectServerInstrumenter.End(ctx, request, connectResponse{}, err)
+		return resp, err
+	}
+}
+
+func (i *connectInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		if !connectEnabler.Enable() {
+			return next(ctx, spec)
+		}
+		request := connectRequest{
+			procedure: spec.Procedure,
+		}
+		ctx = connectClientInstrumenter.Start(ctx, request)
+		conn := next(ctx, spec)
+		request.serverAddress = conn.Peer().Addr
+		request.header = conn.RequestHeader()
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.header))
+		return &streamingClientConn{
+			StreamingClientConn: conn,
+			ctx:                 ctx,
+			request:             request,
+		}
+	}
+}
+
+func (i *connectInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !connectEnabler.Enable() {
+			return next(ctx, conn)
+		}
+		request := connectRequest{
+			procedure:     conn.Spec().Procedure,
+			serverAddress: localAddr(ctx),
+			header:        conn.RequestHeader(),
+		}
+		if connectServerSuppressor.ShouldSuppress(ctx, trace.SpanKindServer) {
+			return serveInHttpSpan(ctx, request, func() error {
+				return next(ctx, conn)
+			})
+		}
+		ctx = connectServerInstrumenter.Start(extract(ctx, request.header), request)
+		err := next(ctx, conn)
+		connectServerInstrumenter.End(ctx, request, connectResponse{}, err)
+		return err
+	}
+}
+
+// serveInHttpSpan records a call on the span of the net/http server it is
+// served by, as a span of its own would only duplicate it, like the go-zero
+// rest middleware does. The net/http span is named after the procedure and
+// gets the RPC attributes and status, and the RPC server metrics are recorded
+// as for a span of the handler.
+func serveInHttpSpan(ctx context.Context, request connectRequest, serve func() error) error {
+	startTime := time.Now()
+	var startAttributes []attribute.KeyValue
+	for _, extractor := range connectServerAttrsExtractors {
+		startAttributes, _ = extractor.OnStart(startAttributes, ctx, request)
+	}
+	metricsContext := connectServerMetrics.OnBeforeEnd(ctx, startAttributes, startTime)
+	err := serve()
+	var endAttributes []attribute.KeyValue
+	for _, extractor := range connectServerAttrsExtractors {
+		endAttributes, _ = extractor.OnEnd(endAttributes, ctx, request, connectResponse{}, err)
+	}
+	// the suppressor has checked that the span in the context is the one of
+	// the net/http server
+	span := trace.SpanFromContext(ctx)
+	span.SetName(connectServerSpanNameExtractor.Extract(request))
+	span.SetAttributes(startAttributes...)
+	span.SetAttributes(endAttributes...)
+	connectServerStatusCodeExtractor.Extract(span, request, connectResponse{}, err)
+	connectServerMetrics.OnAfterEnd(metricsContext, endAttributes, time.Now())
+	return err
+}
+
+// streamingClientConn ends the span of a streaming call when the response is
+// closed, with the first error the stream failed with.
+type streamingClientConn struct {
+	connect.StreamingClientConn
+	ctx     context.Context
+	request connectRequest
+	mu      sync.Mutex
+	err     error
+	once    sync.Once
+}
+
+func (c *streamingClientConn) Send(msg any) error {
+	err := c.StreamingClientConn.Send(msg)
+	c.setErr(err)
+	return err
+}
+
+func (c *streamingClientConn) Receive(msg any) error {
+	err := c.StreamingClientConn.Receive(msg)
+	c.setErr(err)
+	return err
+}
+
+func (c *streamingClientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	c.once.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		connectClientInstrumenter.End(c.ctx, c.request, connectResponse{}, c.err)
+	})
+	return err
+}
+
+func (c *streamingClientConn) setErr(err error) {
+	// Send reports io.EOF when the server has closed the stream and Receive
+	// reports it at the end of the stream, neither is a failure.
+	if err == nil || errors.Is(err, io.EOF) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// extract continues the trace of the caller, unless the handler already runs
+// under a span, e.g. the one of the net/http server that the trace context
+// has been extracted by.
+func extract(ctx context.Context, header http.Header) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+func localAddr(ctx context.Context) string {
+	if addr, ok := ctx.Value(http.LocalAddrContextKey).(net.Addr); ok {
+		return addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import (
+	"context"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var connectEnabler = config.NewInstrumentationEnabler("connect")
+
+type connectAttrsGetter struct {
+}
+
+func (c connectAttrsGetter) GetSystem(request connectRequest) string {
+	return "connect_rpc"
+}
+
+// GetService returns the service part of a procedure like
+// /acme.foo.v1.FooService/Bar.
+func (c connectAttrsGetter) GetService(request connectRequest) string {
+	procedure := strings.TrimPrefix(request.procedure, "/")
+	slashIndex := strings.LastIndex(procedure, "/")
+	if slashIndex == -1 {
+		return ""
+	}
+	return procedure[0:slashIndex]
+}
+
+func (c connectAttrsGetter) GetMethod(request connectRequest) string {
+	slashIndex := strings.LastIndex(request.procedure, "/")
+	if slashIndex == -1 {
+		return ""
+	}
+	return request.procedure[slashIndex+1:]
+}
+
+func (c connectAttrsGetter) GetServerAddress(request connectRequest) string {
+	return request.serverAddress
+}
+
+type connectStatusCodeExtractor[REQUEST connectRequest, RESPONSE connectResponse] struct {
+}
+
+func (c connectStatusCodeExtractor[REQUEST, RESPONSE]) Extract(span trace.Span, request connectRequest, response connectResponse, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// connectErrorCodeExtractor records the Connect error code of failed calls,
+// e.g. not_found or unavailable.
+type connectErrorCodeExtractor struct {
+}
+
+func (c *connectErrorCodeExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request connectRequest) ([]attribute.KeyValue, context.Context) {
+	return attributes, parentContext
+}
+
+func (c *connectErrorCodeExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request connectRequest, response connectResponse, err error) ([]attribute.KeyValue, context.Context) {
+	if err != nil {
+		attributes = append(attributes, semconv.RPCConnectRPCErrorCodeKey.String(connect.CodeOf(err).String()))
+	}
+	return attributes, ctx
+}
+
+func BuildConnectClientInstrumenter() instrumenter.Instrumenter[connectRequest, connectResponse] {
+	builder := instrumenter.Builder[connectRequest, connectResponse]{}
+	clientGetter := connectAttrsGetter{}
+	return builder.Init().SetSpanStatusExtractor(&connectStatusCodeExtractor[connectRequest, connectResponse]{}).SetSpanNameExtractor(&rpc.RpcSpanNameExtractor[connectRequest]{Getter: clientGetter}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[connectRequest]{}).
+		AddAttributesExtractor(&rpc.ClientRpcAttrsExtractor[connectRequest, connectResponse, connectAttrsGetter]{}).
+		AddAttributesExtractor(&connectErrorCodeExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.CONNECT_CLIENT_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddOperationListeners(rpc.RpcClientMetrics("connect.client")).
+		BuildInstrumenter()
+}
+
+// The extractors and metrics of the server instrumenter are shared with
+// serveInHttpSpan, which records the handler on the net/http server span.
+var (
+	connectServerSpanNameExtractor   = &rpc.RpcSpanNameExtractor[connectRequest]{Getter: connectAttrsGetter{}}
+	connectServerStatusCodeExtractor = &connectStatusCodeExtractor[connectRequest, connectResponse]{}
+	connectServerAttrsExtractors     = []instrumenter.AttributesExtractor[connectRequest, connectResponse]{
+		&rpc.ServerRpcAttrsExtractor[connectRequest, connectResponse, connectAttrsGetter]{},
+		&connectErrorCodeExtractor{},
+	}
+	connectServerMetrics = rpc.RpcServerMetrics("connect.server")
+)
+
+func BuildConnectServerInstrumenter() instrumenter.Instrumenter[connectRequest, connectResponse] {
+	builder := instrumenter.Builder[connectRequest, connectResponse]{}
+	return builder.Init().SetSpanStatusExtractor(connectServerStatusCodeExtractor).SetSpanNameExtractor(connectServerSpanNameExtractor).
+		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[connectRequest]{}).
+		AddAttributesExtractor(connectServerAttrsExtractors...).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.CONNECT_SERVER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		AddOperationListeners(connectServerMetrics).
+		BuildInstrumenter()
+}
@@ -20,5 +20,10 @@ type databaseSqlRequest struct {
 	endpoint   string
 	driverName string
 	dsn        string
+	namespace  string
 	params     []any
 }
+
+type databaseSqlResponse struct {
+	returnedRows int64
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
 	"github.com/xwb1989/sqlparser"
 )
 
@@ -45,6 +46,21 @@ func getCollection(sql string) string {
 	return extractCollection(sql)
 }
 
+func getSanitizedStatement(driverName, sql string) string {
+	meta, found := sqlCache.Get(sql)
+	if found && meta.sanitized != "" && meta.sanitizedFor == driverName {
+		return meta.sanitized
+	}
+	sanitized := db.SanitizeSql(driverName, sql)
+	if found {
+		updatedMeta := meta
+		updatedMeta.sanitized = sanitized
+		updatedMeta.sanitizedFor = driverName
+		sqlCache.Add(sql, updatedMeta)
+	}
+	return sanitized
+}
+
 func getParams(sql string) []any {
 	meta, found := sqlCache.Get(sql)
 	if found && len(meta.params) > 0 {
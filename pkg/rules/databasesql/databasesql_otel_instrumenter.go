@@ -26,15 +26,7 @@ type databaseSqlAttrsGetter struct {
 }
 
 func (d databaseSqlAttrsGetter) GetSystem(request databaseSqlRequest) string {
-	switch request.driverName {
-	case "mysql":
-		return "mysql"
-	case "postgres":
-		fallthrough
-	case "postgresql":
-		return "postgresql"
-	}
-	return "database"
+	return db.DbSystemOf(request.driverName)
 }
 
 func (d databaseSqlAttrsGetter) GetServerAddress(request databaseSqlRequest) string {
@@ -48,6 +40,11 @@ func (d databaseSqlAttrsGetter) GetStatement(request databaseSqlRequest) string
 	return request.sql
 }
 
+func (d databaseSqlAttrsGetter) GetSanitizedStatement(request databaseSqlRequest) string {
+	extractSQLMetadata(request)
+	return getSanitizedStatement(request.driverName, request.sql)
+}
+
 func (d databaseSqlAttrsGetter) GetOperation(request databaseSqlRequest) string {
 	return request.opType
 }
@@ -66,8 +63,15 @@ func (d databaseSqlAttrsGetter) GetParameters(request databaseSqlRequest) []any
 	return getParams(request.sql)
 }
 
+func (d databaseSqlAttrsGetter) GetReturnedRows(request databaseSqlRequest, response any) int64 {
+	if r, ok := response.(databaseSqlResponse); ok {
+		return r.returnedRows
+	}
+	return -1
+}
+
 func (d databaseSqlAttrsGetter) GetDbNamespace(request databaseSqlRequest) string {
-	return ""
+	return request.namespace
 }
 
 func (d databaseSqlAttrsGetter) GetBatchSize(request databaseSqlRequest) int {
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"path"
+	"reflect"
+	"sync"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+)
+
+var (
+	poolMu    sync.Mutex
+	poolUnreg = map[*sql.DB]func(){}
+)
+
+// registerPoolMetrics reports the pool metrics of sqlDB. sql.Open builds
+// the pool through sql.OpenDB, so a registration made by the OpenDB hook is
+// replaced once Open knows the driver name and the endpoint.
+func registerPoolMetrics(sqlDB *sql.DB, driver, endpoint string) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if unregister, ok := poolUnreg[sqlDB]; ok {
+		unregister()
+	}
+	poolUnreg[sqlDB] = db.RegisterDbClientPool(driver, endpoint, func() db.DbClientPoolStats {
+		s := sqlDB.Stats()
+		return db.DbClientPoolStats{
+			MaxOpenConnections: int64(s.MaxOpenConnections),
+			InUse:              int64(s.InUse),
+			Idle:               int64(s.Idle),
+			WaitCount:          s.WaitCount,
+			WaitDuration:       s.WaitDuration,
+			MaxIdleClosed:      s.MaxIdleClosed,
+			MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  s.MaxLifetimeClosed,
+		}
+	})
+}
+
+// driverNames holds the names every driver type was registered with. Drivers
+// register themselves from their init, possibly before this package is
+// initialized, so the map is created by the first registration.
+var (
+	driverNamesMu sync.Mutex
+	driverNames   map[reflect.Type][]string
+)
+
+func driverType(d driver.Driver) reflect.Type {
+	t := reflect.TypeOf(d)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+//go:linkname beforeRegisterInstrumentation database/sql.beforeRegisterInstrumentation
+func beforeRegisterInstrumentation(call api.CallContext, name string, d driver.Driver) {
+	if d == nil {
+		return
+	}
+	driverNamesMu.Lock()
+	defer driverNamesMu.Unlock()
+	if driverNames == nil {
+		driverNames = map[reflect.Type][]string{}
+	}
+	t := driverType(d)
+	driverNames[t] = append(driverNames[t], name)
+}
+
+// connectorDriverName names the driver of a connector passed to sql.OpenDB
+// after the name its driver was registered with, preferring a name with a DSN
+// parser, e.g. "postgres" for lib/pq. Drivers that were not registered are
+// named after their package, e.g. "mysql".
+func connectorDriverName(c driver.Connector) string {
+	t := driverType(c.Driver())
+	driverNamesMu.Lock()
+	names := driverNames[t]
+	driverNamesMu.Unlock()
+	for _, name := range names {
+		if db.IsKnownDriver(name) {
+			return name
+		}
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return path.Base(t.PkgPath())
+}
+
+//go:linkname beforeOpenDBInstrumentation database/sql.beforeOpenDBInstrumentation
+func beforeOpenDBInstrumentation(call api.CallContext, c driver.Connector) {
+	if !dbSqlEnabler.Enable() || c == nil {
+		return
+	}
+	call.SetData(connectorDriverName(c))
+}
+
+//go:linkname afterOpenDBInstrumentation database/sql.afterOpenDBInstrumentation
+func afterOpenDBInstrumentation(call api.CallContext, sqlDB *sql.DB) {
+	if !dbSqlEnabler.Enable() || sqlDB == nil {
+		return
+	}
+	driverName, ok := call.GetData().(string)
+	if !ok {
+		return
+	}
+	sqlDB.DriverName = driverName
+	registerPoolMetrics(sqlDB, driverName, sqlDB.Endpoint)
+}
+
+//go:linkname beforeCloseInstrumentation database/sql.beforeCloseInstrumentation
+func beforeCloseInstrumentation(call api.CallContext, sqlDB *sql.DB) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if unregister, ok := poolUnreg[sqlDB]; ok {
+		unregister()
+		delete(poolUnreg, sqlDB)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+)
+
+const (
+	rowsTracingOff   = "off"
+	rowsTracingQuery = "query"
+	rowsTracingFetch = "fetch"
+)
+
+// By default the query span ends once QueryContext returns, "query" keeps it
+// open until the rows are closed and "fetch" adds a child span covering the
+// iteration instead. Unknown values behave like "off".
+var rowsTracing = config.String("databasesql", "rows.tracing", rowsTracingOff,
+	"How to trace the iteration of sql.Rows: off, query or fetch.")
+
+// rowsState is stored in the injected sql.Rows.OtelState field while the
+// rows are being iterated.
+type rowsState struct {
+	ctx     context.Context
+	request databaseSqlRequest
+	count   atomic.Int64
+	mu      sync.Mutex
+	err     error
+	once    sync.Once
+}
+
+func (s *rowsState) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *rowsState) end(err error) {
+	s.once.Do(func() {
+		s.setErr(err)
+		s.mu.Lock()
+		err = s.err
+		s.mu.Unlock()
+		databaseSqlInstrumenter.End(s.ctx, s.request, databaseSqlResponse{returnedRows: s.count.Load()}, err)
+	})
+}
+
+func instrumentQueryEnd(call api.CallContext, rows *sql.Rows, err error) {
+	mode := rowsTracing.Get()
+	if err != nil || rows == nil || (mode != rowsTracingQuery && mode != rowsTracingFetch) {
+		instrumentEnd(call, err)
+		return
+	}
+	callData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	dbRequest, ok := callData["dbRequest"].(databaseSqlRequest)
+	if !ok {
+		return
+	}
+	newCtx, ok := callData["newCtx"].(context.Context)
+	if !ok {
+		return
+	}
+	if mode == rowsTracingFetch {
+		databaseSqlInstrumenter.End(newCtx, dbRequest, nil, nil)
+		dbRequest.opType = "fetch"
+		newCtx = databaseSqlInstrumenter.Start(newCtx, dbRequest)
+	}
+	rows.OtelState = &rowsState{ctx: newCtx, request: dbRequest}
+}
+
+//go:linkname beforeRowsNextInstrumentation database/sql.beforeRowsNextInstrumentation
+func beforeRowsNextInstrumentation(call api.CallContext, rows *sql.Rows) {
+	if state, ok := rows.OtelState.(*rowsState); ok {
+		call.SetData(state)
+	}
+}
+
+//go:linkname afterRowsNextInstrumentation database/sql.afterRowsNextInstrumentation
+func afterRowsNextInstrumentation(call api.CallContext, hasNext bool) {
+	if state, ok := call.GetData().(*rowsState); ok && hasNext {
+		state.count.Add(1)
+	}
+}
+
+//go:linkname beforeRowsErrInstrumentation database/sql.beforeRowsErrInstrumentation
+func beforeRowsErrInstrumentation(call api.CallContext, rows *sql.Rows) {
+	if state, ok := rows.OtelState.(*rowsState); ok {
+		call.SetData(state)
+	}
+}
+
+//go:linkname afterRowsErrInstrumentation database/sql.afterRowsErrInstrumentation
+func afterRowsErrInstrumentation(call api.CallContext, err error) {
+	if state, ok := call.GetData().(*rowsState); ok && err != nil {
+		state.setErr(err)
+	}
+}
+
+//go:linkname beforeRowsCloseInstrumentation database/sql.beforeRowsCloseInstrumentation
+func beforeRowsCloseInstrumentation(call api.CallContext, rows *sql.Rows) {
+	if _, ok := rows.OtelState.(*rowsState); ok {
+		call.SetData(rows)
+	}
+}
+
+//go:linkname afterRowsCloseInstrumentation database/sql.afterRowsCloseInstrumentation
+func afterRowsCloseInstrumentation(call api.CallContext, err error) {
+	rows, ok := call.GetData().(*sql.Rows)
+	if !ok {
+		return
+	}
+	state, ok := rows.OtelState.(*rowsState)
+	if !ok {
+		return
+	}
+	// Rows.Next closes the rows itself once they are exhausted, so the
+	// iteration error is fetched here rather than waiting for the caller to
+	// check it. Rows.Err is safe to call as Close holds no lock by now.
+	_ = rows.Err()
+	state.end(err)
+}
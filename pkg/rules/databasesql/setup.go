@@ -18,25 +18,18 @@ import (
 	"context"
 	"database/sql"
 	"log"
-	"os"
 	"strings"
 
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 )
 
 var databaseSqlInstrumenter = BuildDatabaseSqlOtelInstrumenter()
 
-type dbSqlInnerEnabler struct {
-	enabled bool
-}
-
-func (d dbSqlInnerEnabler) Enable() bool {
-	return d.enabled
-}
-
-var dbSqlEnabler = dbSqlInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_DATABASESQL_ENABLED") != "false"}
+var dbSqlEnabler = config.NewInstrumentationEnabler("databasesql")
 
 const (
 	cacheUpperBound = 1024
@@ -57,12 +50,12 @@ func beforeOpenInstrumentation(call api.CallContext, driverName, dataSourceName
 	if !dbSqlEnabler.Enable() {
 		return
 	}
-	addr, err := parseDSN(driverName, dataSourceName)
+	info, err := db.ParseDSN(driverName, dataSourceName)
 	if err != nil {
 		log.Printf("failed to parse dsn: %v", err)
 	}
 	call.SetData(map[string]string{
-		"endpoint": addr,
+		"endpoint": info.Addr(),
 		"driver":   driverName,
 		"dsn":      dataSourceName,
 	})
@@ -92,6 +85,7 @@ func afterOpenInstrumentation(call api.CallContext, db *sql.DB, err error) {
 	if ok {
 		db.DSN = dsn
 	}
+	registerPoolMetrics(db, db.DriverName, db.Endpoint)
 }
 
 //go:linkname beforePingContextInstrumentation database/sql.beforePingContextInstrumentation
@@ -184,7 +178,7 @@ func afterQueryContextInstrumentation(call api.CallContext, rows *sql.Rows, err
 	if !dbSqlEnabler.Enable() {
 		return
 	}
-	instrumentEnd(call, err)
+	instrumentQueryEnd(call, rows, err)
 }
 
 //go:linkname beforeTxInstrumentation database/sql.beforeTxInstrumentation
@@ -351,7 +345,7 @@ func afterConnQueryContextInstrumentation(call api.CallContext, rows *sql.Rows,
 	if !dbSqlEnabler.Enable() {
 		return
 	}
-	instrumentEnd(call, err)
+	instrumentQueryEnd(call, rows, err)
 }
 
 //go:linkname beforeConnTxInstrumentation database/sql.beforeConnTxInstrumentation
@@ -486,7 +480,7 @@ func afterTxQueryContextInstrumentation(call api.CallContext, rows *sql.Rows, er
 	if !dbSqlEnabler.Enable() {
 		return
 	}
-	instrumentEnd(call, err)
+	instrumentQueryEnd(call, rows, err)
 }
 
 //go:linkname beforeTxCommitInstrumentation database/sql.beforeTxCommitInstrumentation
@@ -570,7 +564,7 @@ func afterStmtQueryContextInstrumentation(call api.CallContext, rows *sql.Rows,
 	if !dbSqlEnabler.Enable() {
 		return
 	}
-	instrumentEnd(call, err)
+	instrumentQueryEnd(call, rows, err)
 }
 func instrumentStart(call api.CallContext, ctx context.Context, spanName, query, endpoint, driverName, dsn string, args ...any) {
 	req := databaseSqlRequest{
@@ -579,6 +573,7 @@ func instrumentStart(call api.CallContext, ctx context.Context, spanName, query,
 		endpoint:   endpoint,
 		driverName: driverName,
 		dsn:        dsn,
+		namespace:  db.LookupDSN(driverName, dsn).Database,
 		params:     args,
 	}
 	newCtx := databaseSqlInstrumenter.Start(ctx, req)
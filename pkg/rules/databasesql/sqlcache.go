@@ -24,6 +24,10 @@ type SQLMeta struct {
 	operation  string
 	collection string
 	params     []any
+	// sanitized is the statement with its literals masked, filled lazily for
+	// the driver in sanitizedFor, as the quoting of the drivers differs.
+	sanitized    string
+	sanitizedFor string
 }
 
 type SQLMetaCache struct {
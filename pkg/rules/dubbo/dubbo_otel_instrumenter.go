@@ -15,9 +15,8 @@
 package dubbo
 
 import (
-	"os"
-
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
@@ -26,15 +25,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-type dubboEnable struct {
-	enable bool
-}
-
-func (d *dubboEnable) Enable() bool {
-	return d.enable
-}
-
-var dubboEnabler = dubboEnable{os.Getenv("OTEL_INSTRUMENTATION_DUBBO_ENABLED") != "false"}
+var dubboEnabler = config.NewInstrumentationEnabler("dubbo")
 
 type dubboAttrsGetter struct{}
 
@@ -15,21 +15,13 @@
 package eino
 
 import (
-	"os"
 	"sync"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/cloudwego/eino/schema"
 )
 
-type einoInnerEnabler struct {
-	enabled bool
-}
-
-func (l einoInnerEnabler) Enable() bool {
-	return l.enabled
-}
-
-var einoEnabler = einoInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_EINO_ENABLED") != "false"}
+var einoEnabler = config.NewInstrumentationEnabler("eino")
 
 type (
 	promptRequestKey    struct{}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import "context"
+
+type execRequest struct {
+	command string
+	args    []string
+}
+
+type execResponse struct {
+	pid      int
+	exitCode int
+}
+
+// cmdSpan is stored in the injected exec.Cmd.OtelSpan field from Start until
+// Wait returns
+type cmdSpan struct {
+	ctx     context.Context
+	request execRequest
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/process"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+type execAttrsGetter struct{}
+
+func (e execAttrsGetter) GetCommand(request execRequest) string {
+	return request.command
+}
+
+func (e execAttrsGetter) GetCommandArgs(request execRequest) []string {
+	return request.args
+}
+
+func (e execAttrsGetter) GetPid(request execRequest, response execResponse) int {
+	return response.pid
+}
+
+func (e execAttrsGetter) GetExitCode(request execRequest, response execResponse) int {
+	return response.exitCode
+}
+
+func BuildExecOtelInstrumenter() instrumenter.Instrumenter[execRequest, execResponse] {
+	builder := instrumenter.Builder[execRequest, execResponse]{}
+	getter := execAttrsGetter{}
+	return builder.Init().SetSpanNameExtractor(&process.ProcessSpanNameExtractor[execRequest, execResponse]{Getter: getter}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[execRequest]{}).
+		AddAttributesExtractor(&process.ProcessAttrsExtractor[execRequest, execResponse, execAttrsGetter]{Getter: getter}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.OS_EXEC_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
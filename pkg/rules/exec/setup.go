@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"os/exec"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/process"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"go.opentelemetry.io/otel"
+)
+
+var execEnabler = config.NewInstrumentationEnabler("osexec")
+
+var execInstrumenter = BuildExecOtelInstrumenter()
+
+//go:linkname beforeCmdStart os/exec.beforeCmdStart
+func beforeCmdStart(call api.CallContext, c *exec.Cmd) {
+	if !execEnabler.Enable() {
+		return
+	}
+	// Starting a command twice fails without starting anything
+	if c.Process != nil || c.OtelSpan != nil {
+		return
+	}
+	request := execRequest{command: c.Path, args: c.Args}
+	ctx := execInstrumenter.Start(context.Background(), request)
+	// The child process continues the trace if it is instrumented as well
+	carrier := &process.EnvCarrier{Env: c.Environ()}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	c.Env = carrier.Env
+	c.OtelSpan = &cmdSpan{ctx: ctx, request: request}
+	call.SetData(c)
+}
+
+//go:linkname afterCmdStart os/exec.afterCmdStart
+func afterCmdStart(call api.CallContext, err error) {
+	c, ok := call.GetData().(*exec.Cmd)
+	if !ok || err == nil {
+		return
+	}
+	endCmdSpan(c, err)
+}
+
+//go:linkname beforeCmdWait os/exec.beforeCmdWait
+func beforeCmdWait(call api.CallContext, c *exec.Cmd) {
+	call.SetData(c)
+}
+
+//go:linkname afterCmdWait os/exec.afterCmdWait
+func afterCmdWait(call api.CallContext, err error) {
+	c, ok := call.GetData().(*exec.Cmd)
+	if !ok {
+		return
+	}
+	endCmdSpan(c, err)
+}
+
+func endCmdSpan(c *exec.Cmd, err error) {
+	span, ok := c.OtelSpan.(*cmdSpan)
+	if !ok {
+		return
+	}
+	c.OtelSpan = nil
+	response := execResponse{exitCode: -1}
+	if c.Process != nil {
+		response.pid = c.Process.Pid
+	}
+	if c.ProcessState != nil {
+		response.exitCode = c.ProcessState.ExitCode()
+	}
+	execInstrumenter.End(span.ctx, span.request, response, err)
+}
@@ -20,9 +20,13 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
 )
 
+var fastHttpClientFilter = utils.NewHttpClientFilter()
+
 var fastHttpClientInstrumenter = BuildFastHttpClientOtelInstrumenter()
 
 //go:linkname clientFastHttpOnEnter github.com/valyala/fasthttp.clientFastHttpOnEnter
@@ -40,10 +44,16 @@ func clientFastHttpOnEnter(call api.CallContext, c *fasthttp.HostClient, req *fa
 		return
 	}
 	request := fastHttpRequest{
-		method: string(req.Header.Method()),
-		url:    u,
-		isTls:  isTLS,
-		header: &req.Header,
+		method:   string(req.Header.Method()),
+		url:      u,
+		isTls:    isTLS,
+		header:   &req.Header,
+		bodySize: requestBodySize(req),
+	}
+	if fastHttpClientFilter.FilterRequest(request.method, u.Host, u.Path) {
+		// no span for the filtered request, but keep the trace going downstream
+		otel.GetTextMapPropagator().Inject(context.Background(), fastHttpRequestCarrier{req: &req.Header})
+		return
 	}
 	ctx := fastHttpClientInstrumenter.Start(context.Background(), request)
 	data := make(map[string]interface{}, 3)
@@ -58,12 +68,16 @@ func clientFastHttpOnExit(call api.CallContext, err error) {
 	if !fastHttpEnabler.Enable() {
 		return
 	}
-	data := call.GetData().(map[string]interface{})
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok || data == nil {
+		return
+	}
 	ctx := data["ctx"].(context.Context)
 	request := data["request"].(fastHttpRequest)
 	resp := data["response"].(*fasthttp.Response)
 	fastHttpClientInstrumenter.End(ctx, request, fastHttpResponse{
 		statusCode: resp.StatusCode(),
 		header:     &resp.Header,
+		bodySize:   responseBodySize(resp),
 	}, err)
 }
@@ -26,9 +26,31 @@ type fastHttpRequest struct {
 	isTls  bool
 	port   int
 	header *fasthttp.RequestHeader
+	// bodySize is the size of the request body, -1 means unknown
+	bodySize int64
 }
 
 type fastHttpResponse struct {
 	statusCode int
 	header     *fasthttp.ResponseHeader
+	// bodySize is the size of the response body, -1 means unknown
+	bodySize int64
+}
+
+// requestBodySize returns the size of the request body without reading a
+// body stream.
+func requestBodySize(req *fasthttp.Request) int64 {
+	if req.IsBodyStream() {
+		return -1
+	}
+	return int64(len(req.Body()))
+}
+
+// responseBodySize returns the size of the response body without reading a
+// body stream.
+func responseBodySize(resp *fasthttp.Response) int64 {
+	if resp.IsBodyStream() {
+		return -1
+	}
+	return int64(len(resp.Body()))
 }
@@ -14,10 +14,10 @@
 package fasthttp
 
 import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"os"
 	"strconv"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/http"
@@ -30,15 +30,7 @@ import (
 
 var emptyFastHttpResponse = fastHttpResponse{}
 
-type fastHttpInnerEnabler struct {
-	enabled bool
-}
-
-func (g fastHttpInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var fastHttpEnabler = fastHttpInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_FASTHTTP_ENABLED") != "false"}
+var fastHttpEnabler = config.NewInstrumentationEnabler("fasthttp")
 
 type fastHttpClientAttrsGetter struct {
 }
@@ -63,6 +55,14 @@ func (n fastHttpClientAttrsGetter) GetHttpResponseHeader(request fastHttpRequest
 	}
 	return all
 }
+func (n fastHttpClientAttrsGetter) GetHttpRequestBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return request.bodySize
+}
+
+func (n fastHttpClientAttrsGetter) GetHttpResponseBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return response.bodySize
+}
+
 func (n fastHttpClientAttrsGetter) GetErrorType(request fastHttpRequest, response fastHttpResponse, err error) string {
 	return ""
 }
@@ -132,6 +132,14 @@ func (n fastHttpServerAttrsGetter) GetHttpResponseHeader(request fastHttpRequest
 	}
 	return all
 }
+func (n fastHttpServerAttrsGetter) GetHttpRequestBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return request.bodySize
+}
+
+func (n fastHttpServerAttrsGetter) GetHttpResponseBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return response.bodySize
+}
+
 func (n fastHttpServerAttrsGetter) GetErrorType(request fastHttpRequest, response fastHttpResponse, err error) string {
 	return ""
 }
@@ -205,6 +213,7 @@ func BuildFastHttpClientOtelInstrumenter() *instrumenter.PropagatingToDownstream
 	networkExtractor := net.NetworkAttrsExtractor[fastHttpRequest, fastHttpResponse, fastHttpClientAttrsGetter]{Getter: clientGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpClientSpanStatusExtractor[fastHttpRequest, fastHttpResponse]{Getter: clientGetter}).SetSpanNameExtractor(&http.HttpClientSpanNameExtractor[fastHttpRequest, fastHttpResponse]{Getter: clientGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[fastHttpRequest]{}).
+		SetSpanNameFilter(fastHttpClientFilter).
 		AddOperationListeners(http.HttpClientMetrics("fasthttp.client")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.FAST_HTTP_CLIENT_SCOPE_NAME,
@@ -222,6 +231,7 @@ func BuildFastHttpServerOtelInstrumenter() *instrumenter.PropagatingFromUpstream
 	urlExtractor := net.UrlAttrsExtractor[fastHttpRequest, fastHttpResponse, fastHttpServerAttrsGetter]{Getter: serverGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[fastHttpRequest, fastHttpResponse]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[fastHttpRequest, fastHttpResponse]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[fastHttpRequest]{}).
+		SetSpanNameFilter(fastHttpServerFilter).
 		AddOperationListeners(http.HttpServerMetrics("fasthttp.server")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.FAST_HTTP_SERVER_SCOPE_NAME,
@@ -20,9 +20,12 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/valyala/fasthttp"
 )
 
+var fastHttpServerFilter = utils.NewHttpServerFilter()
+
 var fastHttpServerInstrumenter = BuildFastHttpServerOtelInstrumenter()
 
 func newFastHttpServerDelegateHandler(handler fasthttp.RequestHandler) fasthttp.RequestHandler {
@@ -33,15 +36,20 @@ func newFastHttpServerDelegateHandler(handler fasthttp.RequestHandler) fasthttp.
 		if err != nil {
 			return
 		}
+		if fastHttpServerFilter.FilterRequest(string(ctx.Method()), string(ctx.Host()), u.Path) {
+			return
+		}
 		request := fastHttpRequest{
-			method: string(ctx.Method()),
-			url:    u,
-			isTls:  ctx.IsTLS(),
-			header: &ctx.Request.Header,
+			method:   string(ctx.Method()),
+			url:      u,
+			isTls:    ctx.IsTLS(),
+			header:   &ctx.Request.Header,
+			bodySize: requestBodySize(&ctx.Request),
 		}
 		fastHttpServerInstrumenter.StartAndEnd(ctx, request, fastHttpResponse{
 			statusCode: ctx.Response.StatusCode(),
 			header:     &ctx.Response.Header,
+			bodySize:   responseBodySize(&ctx.Response),
 		}, ctx.Err(), startTime, time.Now())
 	}
 }
@@ -20,10 +20,13 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/valyala/fasthttp"
 )
 
+var fiberv2ServerFilter = utils.NewHttpServerFilter()
+
 var fiberv2ServerInstrumenter = BuildFiberV2ServerOtelInstrumenter()
 
 //go:linkname fiberHttpOnEnterv2 github.com/gofiber/fiber/v2.fiberHttpOnEnterv2
@@ -35,11 +38,15 @@ func fiberHttpOnEnterv2(call api.CallContext, app *fiber.App, ctx *fasthttp.Requ
 	if err != nil {
 		return
 	}
+	if fiberv2ServerFilter.FilterRequest(string(ctx.Method()), string(ctx.Host()), u.Path) {
+		return
+	}
 	request := &fiberv2Request{
-		method: string(ctx.Method()),
-		url:    u,
-		isTls:  ctx.IsTLS(),
-		header: &ctx.Request.Header,
+		method:   string(ctx.Method()),
+		url:      u,
+		isTls:    ctx.IsTLS(),
+		header:   &ctx.Request.Header,
+		bodySize: requestBodySize(&ctx.Request),
 	}
 	ctxSpan := fiberv2ServerInstrumenter.Start(ctx, request)
 	data := make(map[string]interface{}, 2)
@@ -65,6 +72,7 @@ func fiberHttpOnExitv2(call api.CallContext) {
 	fiberv2ServerInstrumenter.End(ctxSpan, request, &fiberv2Response{
 		statusCode: ctx.Response.StatusCode(),
 		header:     &ctx.Response.Header,
+		bodySize:   responseBodySize(&ctx.Response),
 	}, nil)
 
 }
@@ -14,9 +14,9 @@
 package fiberv2
 
 import (
-	"os"
 	"strconv"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -31,15 +31,7 @@ import (
 
 var emptyFiberv2Response = fiberv2Response{}
 
-type fiberV2InnerEnabler struct {
-	enabled bool
-}
-
-func (g fiberV2InnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var fiberV2Enabler = fiberV2InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_FIBERV2_ENABLED") != "false"}
+var fiberV2Enabler = config.NewInstrumentationEnabler("fiberv2")
 
 type fiberv2ServerAttrsGetter struct {
 }
@@ -64,6 +56,14 @@ func (n fiberv2ServerAttrsGetter) GetHttpResponseHeader(request *fiberv2Request,
 	}
 	return all
 }
+func (n fiberv2ServerAttrsGetter) GetHttpRequestBodySize(request *fiberv2Request, response *fiberv2Response) int64 {
+	return request.bodySize
+}
+
+func (n fiberv2ServerAttrsGetter) GetHttpResponseBodySize(request *fiberv2Request, response *fiberv2Response) int64 {
+	return response.bodySize
+}
+
 func (n fiberv2ServerAttrsGetter) GetErrorType(request *fiberv2Request, response *fiberv2Response, err error) string {
 	return ""
 }
@@ -139,6 +139,7 @@ func BuildFiberV2ServerOtelInstrumenter() *instrumenter.PropagatingFromUpstreamI
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[*fiberv2Request, *fiberv2Response]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[*fiberv2Request, *fiberv2Response]{Getter: serverGetter}).
 		AddOperationListeners(http.HttpServerMetrics("fiberv2.server")).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[*fiberv2Request]{}).
+		SetSpanNameFilter(fiberv2ServerFilter).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.FIBER_V2_SERVER_SCOPE_NAME,
 			Version: version.Tag,
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// franzProcessSpan is stored in the injected FetchesRecordIter.OtelProcessSpan
+// field, it is the process span of the last record returned by the iterator.
+// The span ends when the iterator is asked for the next record, which
+// Fetches.EachRecord does once the callback returns. The span of an iterator
+// that is dropped before it is done is never ended, and is released with it.
+type franzProcessSpan struct {
+	ctx context.Context
+	req franzRecordReq
+}
+
+func startProcessSpan(iter *kgo.FetchesRecordIter, record *kgo.Record) {
+	endProcessSpan(iter)
+	req := franzRecordReq{record: record}
+	// the record was counted by the receive span of its poll
+	ctx := processInstrumenter.Start(message.ContextWithReceived(context.Background()), req)
+	iter.OtelProcessSpan = &franzProcessSpan{ctx: ctx, req: req}
+}
+
+func endProcessSpan(iter *kgo.FetchesRecordIter) {
+	span, ok := iter.OtelProcessSpan.(*franzProcessSpan)
+	if !ok {
+		return
+	}
+	iter.OtelProcessSpan = nil
+	processInstrumenter.End(span.ctx, span.req, nil, nil)
+}
+
+// processEachRecord wraps the callback of FetchTopic.EachRecord and
+// FetchPartition.EachRecord with the process span of every record
+func processEachRecord(fn func(*kgo.Record)) func(*kgo.Record) {
+	return func(record *kgo.Record) {
+		if record == nil {
+			fn(record)
+			return
+		}
+		req := franzRecordReq{record: record}
+		ctx := processInstrumenter.Start(message.ContextWithReceived(context.Background()), req)
+		defer processInstrumenter.End(ctx, req, nil, nil)
+		fn(record)
+	}
+}
+
+// receiveFetches records a single receive span for the records of a poll,
+// linked to the producer of every record
+func receiveFetches(parentContext context.Context, startTimestamp time.Time, fetches kgo.Fetches) {
+	endTimestamp := time.Now()
+	var records []*kgo.Record
+	var links []trace.Link
+	// Fetches.EachRecord is not used here, its iterator is traced
+	for _, fetch := range fetches {
+		for _, topic := range fetch.Topics {
+			for _, partition := range topic.Partitions {
+				for _, record := range partition.Records {
+					if record == nil {
+						continue
+					}
+					records = append(records, record)
+					producerContext := otel.GetTextMapPropagator().Extract(context.Background(), franzRecordCarrier{record: record})
+					if spanContext := trace.SpanContextFromContext(producerContext); spanContext.IsValid() {
+						links = append(links, trace.Link{SpanContext: spanContext})
+					}
+				}
+			}
+		}
+	}
+	if len(records) == 0 {
+		return
+	}
+	receiveInstrumenter.StartAndEndWithOptions(
+		parentContext,
+		franzFetchesReq{records: records},
+		nil,
+		nil,
+		startTimestamp,
+		endTimestamp,
+		[]trace.SpanStartOption{trace.WithLinks(links...)},
+		nil,
+	)
+}
+
+//go:linkname clientPollRecordsOnEnter github.com/twmb/franz-go/pkg/kgo.clientPollRecordsOnEnter
+func clientPollRecordsOnEnter(call api.CallContext, _ *kgo.Client, ctx context.Context, maxPollRecords int) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	instrumentationData := map[string]interface{}{
+		"parentContext":  ctx,
+		"startTimestamp": time.Now(),
+	}
+	call.SetData(instrumentationData)
+}
+
+//go:linkname clientPollRecordsOnExit github.com/twmb/franz-go/pkg/kgo.clientPollRecordsOnExit
+func clientPollRecordsOnExit(call api.CallContext, fetches kgo.Fetches) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	parentContext := instrumentationData["parentContext"].(context.Context)
+	startTimestamp := instrumentationData["startTimestamp"].(time.Time)
+	receiveFetches(parentContext, startTimestamp, fetches)
+}
+
+//go:linkname fetchesRecordIterNextOnEnter github.com/twmb/franz-go/pkg/kgo.fetchesRecordIterNextOnEnter
+func fetchesRecordIterNextOnEnter(call api.CallContext, iter *kgo.FetchesRecordIter) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if iter == nil {
+		return
+	}
+	endProcessSpan(iter)
+	call.SetData(iter)
+}
+
+//go:linkname fetchesRecordIterNextOnExit github.com/twmb/franz-go/pkg/kgo.fetchesRecordIterNextOnExit
+func fetchesRecordIterNextOnExit(call api.CallContext, record *kgo.Record) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	iter, ok := call.GetData().(*kgo.FetchesRecordIter)
+	if !ok || record == nil {
+		return
+	}
+	startProcessSpan(iter, record)
+}
+
+//go:linkname fetchesRecordIterDoneOnEnter github.com/twmb/franz-go/pkg/kgo.fetchesRecordIterDoneOnEnter
+func fetchesRecordIterDoneOnEnter(call api.CallContext, iter *kgo.FetchesRecordIter) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if iter != nil {
+		endProcessSpan(iter)
+	}
+}
+
+//go:linkname fetchTopicEachRecordOnEnter github.com/twmb/franz-go/pkg/kgo.fetchTopicEachRecordOnEnter
+func fetchTopicEachRecordOnEnter(call api.CallContext, _ *kgo.FetchTopic, fn func(*kgo.Record)) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if fn == nil {
+		return
+	}
+	call.SetParam(1, processEachRecord(fn))
+}
+
+//go:linkname fetchPartitionEachRecordOnEnter github.com/twmb/franz-go/pkg/kgo.fetchPartitionEachRecordOnEnter
+func fetchPartitionEachRecordOnEnter(call api.CallContext, _ *kgo.FetchPartition, fn func(*kgo.Record)) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if fn == nil {
+		return
+	}
+	call.SetParam(1, processEachRecord(fn))
+}
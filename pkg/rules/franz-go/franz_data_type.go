@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// franzRecordReq is a record produced or consumed by a kgo.Client
+type franzRecordReq struct {
+	record *kgo.Record
+}
+
+// franzFetchesReq holds the records returned by a single poll of a kgo.Client
+type franzFetchesReq struct {
+	records []*kgo.Record
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"strconv"
+)
+
+var franzEnabler = config.NewInstrumentationEnabler("franz-go")
+
+var (
+	producerInstrumenter = buildFranzProducerInstrumenter()
+	receiveInstrumenter  = buildFranzReceiveInstrumenter()
+	processInstrumenter  = buildFranzProcessInstrumenter()
+)
+
+// franzRecordCarrier reads and writes the trace context from and to the
+// headers of a record
+type franzRecordCarrier struct {
+	record *kgo.Record
+}
+
+func (carrier franzRecordCarrier) Get(key string) string {
+	for _, header := range carrier.record.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (carrier franzRecordCarrier) Set(key, value string) {
+	for i, header := range carrier.record.Headers {
+		if header.Key == key {
+			carrier.record.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	carrier.record.Headers = append(carrier.record.Headers, kgo.RecordHeader{
+		Key:   key,
+		Value: []byte(value),
+	})
+}
+
+func (carrier franzRecordCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.record.Headers))
+	for _, header := range carrier.record.Headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
+
+type franzRecordAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[franzRecordReq, any] = franzRecordAttrsGetter{}
+
+func (getter franzRecordAttrsGetter) GetSystem(request franzRecordReq) string {
+	return "kafka"
+}
+
+func (getter franzRecordAttrsGetter) GetDestination(request franzRecordReq) string {
+	return request.record.Topic
+}
+
+func (getter franzRecordAttrsGetter) GetDestinationTemplate(request franzRecordReq) string {
+	return ""
+}
+
+func (getter franzRecordAttrsGetter) IsTemporaryDestination(request franzRecordReq) bool {
+	return false
+}
+
+func (getter franzRecordAttrsGetter) IsAnonymousDestination(request franzRecordReq) bool {
+	return false
+}
+
+func (getter franzRecordAttrsGetter) GetConversationId(request franzRecordReq) string {
+	return ""
+}
+
+func (getter franzRecordAttrsGetter) GetMessageBodySize(request franzRecordReq) int64 {
+	return int64(len(request.record.Value))
+}
+
+func (getter franzRecordAttrsGetter) GetMessageEnvelopSize(request franzRecordReq) int64 {
+	return 0
+}
+
+func (getter franzRecordAttrsGetter) GetMessageId(request franzRecordReq, response any) string {
+	return ""
+}
+
+func (getter franzRecordAttrsGetter) GetClientId(request franzRecordReq) string {
+	return ""
+}
+
+func (getter franzRecordAttrsGetter) GetBatchMessageCount(request franzRecordReq, response any) int64 {
+	return 1
+}
+
+func (getter franzRecordAttrsGetter) GetMessageHeader(request franzRecordReq, name string) []string {
+	var headerValues []string
+	for _, header := range request.record.Headers {
+		if header.Key == name {
+			headerValues = append(headerValues, string(header.Value))
+		}
+	}
+	return headerValues
+}
+
+func (getter franzRecordAttrsGetter) GetDestinationPartitionId(request franzRecordReq) string {
+	// A produced record only knows its partition once it is acknowledged
+	return ""
+}
+
+type franzFetchesAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[franzFetchesReq, any] = franzFetchesAttrsGetter{}
+
+func (getter franzFetchesAttrsGetter) GetSystem(request franzFetchesReq) string {
+	return "kafka"
+}
+
+// GetDestination returns the topic of the records if they all come from the
+// same topic
+func (getter franzFetchesAttrsGetter) GetDestination(request franzFetchesReq) string {
+	topic := ""
+	for i, record := range request.records {
+		if i > 0 && record.Topic != topic {
+			return ""
+		}
+		topic = record.Topic
+	}
+	return topic
+}
+
+func (getter franzFetchesAttrsGetter) GetDestinationTemplate(request franzFetchesReq) string {
+	return ""
+}
+
+func (getter franzFetchesAttrsGetter) IsTemporaryDestination(request franzFetchesReq) bool {
+	return false
+}
+
+func (getter franzFetchesAttrsGetter) IsAnonymousDestination(request franzFetchesReq) bool {
+	return false
+}
+
+func (getter franzFetchesAttrsGetter) GetConversationId(request franzFetchesReq) string {
+	return ""
+}
+
+func (getter franzFetchesAttrsGetter) GetMessageBodySize(request franzFetchesReq) int64 {
+	var size int64
+	for _, record := range request.records {
+		size += int64(len(record.Value))
+	}
+	return size
+}
+
+func (getter franzFetchesAttrsGetter) GetMessageEnvelopSize(request franzFetchesReq) int64 {
+	return 0
+}
+
+func (getter franzFetchesAttrsGetter) GetMessageId(request franzFetchesReq, response any) string {
+	return ""
+}
+
+func (getter franzFetchesAttrsGetter) GetClientId(request franzFetchesReq) string {
+	return ""
+}
+
+func (getter franzFetchesAttrsGetter) GetBatchMessageCount(request franzFetchesReq, response any) int64 {
+	return int64(len(request.records))
+}
+
+func (getter franzFetchesAttrsGetter) GetMessageHeader(request franzFetchesReq, name string) []string {
+	// The headers differ from record to record, they are recorded by the
+	// process spans
+	return nil
+}
+
+// GetDestinationPartitionId returns the partition of the records if they all
+// come from the same partition of the same topic
+func (getter franzFetchesAttrsGetter) GetDestinationPartitionId(request franzFetchesReq) string {
+	if len(request.records) == 0 || getter.GetDestination(request) == "" {
+		return ""
+	}
+	partition := request.records[0].Partition
+	for _, record := range request.records[1:] {
+		if record.Partition != partition {
+			return ""
+		}
+	}
+	return strconv.Itoa(int(partition))
+}
+
+// franzProducerAttrsExtractor records where the record was produced to
+type franzProducerAttrsExtractor struct{}
+
+func (extractor *franzProducerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request franzRecordReq) ([]attribute.KeyValue, context.Context) {
+	return attributes, parentContext
+}
+
+func (extractor *franzProducerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request franzRecordReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	if err == nil {
+		attributes = append(attributes,
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(request.record.Partition))),
+			semconv.MessagingKafkaOffset(int(request.record.Offset)),
+		)
+	}
+	return attributes, ctx
+}
+
+// franzConsumerAttrsExtractor records where the record was consumed from
+type franzConsumerAttrsExtractor struct{}
+
+func (extractor *franzConsumerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request franzRecordReq) ([]attribute.KeyValue, context.Context) {
+	return append(attributes,
+		semconv.MessagingDestinationPartitionID(strconv.Itoa(int(request.record.Partition))),
+		semconv.MessagingKafkaOffset(int(request.record.Offset)),
+	), parentContext
+}
+
+func (extractor *franzConsumerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request franzRecordReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+func buildFranzProducerInstrumenter() instrumenter.Instrumenter[franzRecordReq, any] {
+	builder := instrumenter.Builder[franzRecordReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzRecordReq, any]{
+			Getter:        franzRecordAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[franzRecordReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzRecordReq, any, franzRecordAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddAttributesExtractor(&franzProducerAttrsExtractor{}).
+		AddOperationListeners(message.MessageMetrics("franz-go.producer")).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request franzRecordReq) propagation.TextMapCarrier {
+				return franzRecordCarrier{record: request.record}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build the receive instrumenter, the receive span of a poll belongs to the
+// trace of the poller and links to the producers instead of continuing their
+// traces
+func buildFranzReceiveInstrumenter() instrumenter.Instrumenter[franzFetchesReq, any] {
+	builder := instrumenter.Builder[franzFetchesReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzFetchesReq, any]{
+			Getter:        franzFetchesAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[franzFetchesReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzFetchesReq, any, franzFetchesAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddOperationListeners(message.MessageMetrics("franz-go.receive")).
+		BuildInstrumenter()
+}
+
+func buildFranzProcessInstrumenter() instrumenter.Instrumenter[franzRecordReq, any] {
+	builder := instrumenter.Builder[franzRecordReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.FRANZGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[franzRecordReq, any]{
+			Getter:        franzRecordAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[franzRecordReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[franzRecordReq, any, franzRecordAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddAttributesExtractor(&franzConsumerAttrsExtractor{}).
+		AddOperationListeners(message.MessageMetrics("franz-go.process")).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request franzRecordReq) propagation.TextMapCarrier {
+				return franzRecordCarrier{record: request.record}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
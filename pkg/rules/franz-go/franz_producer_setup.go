@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package franz
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// startPublishSpan starts the publish span of a record on a short-lived
+// goroutine. The span ends on the goroutine calling the promise, starting it
+// on the caller would leave it in the goroutine local context of the caller
+// and make it the parent of every later span of that goroutine.
+func startPublishSpan(ctx context.Context, req franzRecordReq) context.Context {
+	started := make(chan context.Context, 1)
+	go func() {
+		started <- producerInstrumenter.Start(ctx, req)
+	}()
+	return <-started
+}
+
+// Produce, TryProduce and ProduceSync all go through Client.produce. The
+// publish span starts when the record is handed to the client and ends when
+// its promise is called, that is once Kafka acknowledged the record or the
+// client gave up on it.
+//
+//go:linkname clientProduceOnEnter github.com/twmb/franz-go/pkg/kgo.clientProduceOnEnter
+func clientProduceOnEnter(call api.CallContext, client *kgo.Client, ctx context.Context, record *kgo.Record, promise func(*kgo.Record, error), block bool) {
+	if !franzEnabler.Enable() {
+		return
+	}
+	if record == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if record.Topic == "" {
+		// Name the span after the topic the client falls back to
+		if topic, ok := client.OptValue(kgo.DefaultProduceTopic).(string); ok {
+			record.Topic = topic
+		}
+	}
+	req := franzRecordReq{record: record}
+	spanContext := startPublishSpan(ctx, req)
+	call.SetParam(3, func(record *kgo.Record, err error) {
+		producerInstrumenter.End(spanContext, req, nil, err)
+		if promise != nil {
+			promise(record, err)
+		}
+	})
+}
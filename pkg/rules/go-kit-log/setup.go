@@ -15,22 +15,14 @@
 package log
 
 import (
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type kitlogInnerEnabler struct {
-	enabled bool
-}
-
-func (g kitlogInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var kitlogEnabler = kitlogInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GOKITLOG_ENABLED") != "false"}
+var kitlogEnabler = config.NewInstrumentationEnabler("gokitlog")
 
 //go:linkname logfmtLoggerLogOnEnter github.com/go-kit/log.logfmtLoggerLogOnEnter
 func logfmtLoggerLogOnEnter(call api.CallContext, _ interface{}, keyVals ...interface{}) {
@@ -36,6 +36,10 @@ func (g gogpAttrsGetter) GetStatement(gocqlRequest gocqlRequest) string {
 	return gocqlRequest.Statement
 }
 
+func (g gogpAttrsGetter) GetSanitizedStatement(gocqlRequest gocqlRequest) string {
+	return db.SanitizeSql("cassandra", gocqlRequest.Statement)
+}
+
 func (g gogpAttrsGetter) GetCollection(_ gocqlRequest) string {
 	// TBD: We need to implement retrieving the collection later.
 	return ""
@@ -17,23 +17,13 @@ package gocql
 import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/gocql/gocql"
-	"os"
 	"strings"
 	_ "unsafe"
 )
 
-type gocqlInnerEnabler struct {
-	enabled bool
-}
-
-func (g gocqlInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var gocqlEnabler = gocqlInnerEnabler{
-	enabled: os.Getenv("OTEL_INSTRUMENTATION_GOCQL_ENABLED") != "false",
-}
+var gocqlEnabler = config.NewInstrumentationEnabler("gocql")
 
 var gocqlInstrumenter = BuildGocqlInstrumenter()
 
@@ -16,23 +16,15 @@ package golog
 
 import (
 	"log"
-	"os"
 	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type glogInnerEnabler struct {
-	enabled bool
-}
-
-func (g glogInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var glogEnabler = glogInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GLOG_ENABLED") != "false"}
+var glogEnabler = config.NewInstrumentationEnabler("glog")
 
 //go:linkname goLogWriteOnEnter log.goLogWriteOnEnter
 func goLogWriteOnEnter(call api.CallContext, ce *log.Logger, pc uintptr, calldepth int, appendOutput func([]byte) []byte) {
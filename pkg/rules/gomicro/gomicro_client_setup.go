@@ -19,6 +19,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	micro "go-micro.dev/v5"
 	"go-micro.dev/v5/client"
 	"go-micro.dev/v5/metadata"
@@ -30,6 +31,8 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 )
 
+var goMicroClientFilter = utils.NewHttpClientFilter()
+
 type clientV5Wrapper struct {
 	client.Client
 }
@@ -43,6 +46,13 @@ func (s *clientV5Wrapper) Call(ctx context.Context, req client.Request, rsp inte
 	if !goMicroEnabler.Enable() {
 		return s.Client.Call(ctx, req, rsp, opts...)
 	}
+	if goMicroClientFilter.FilterRequest(req.Method(), req.Service(), req.Endpoint()) {
+		// no span for the filtered request, but keep the trace going downstream
+		mda, _ := metadata.FromContext(ctx)
+		md := metadata.Copy(mda)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(md))
+		return s.Client.Call(metadata.NewContext(ctx, md), req, rsp, opts...)
+	}
 	request := goMicroRequest{
 		request: req,
 		reqType: CallRequest,
@@ -68,6 +78,9 @@ func (s *clientV5Wrapper) Stream(ctx context.Context, req client.Request, opts .
 	if !goMicroEnabler.Enable() {
 		return s.Client.Stream(ctx, req, opts...)
 	}
+	if goMicroClientFilter.FilterRequest(req.Method(), req.Service(), req.Endpoint()) {
+		return s.Client.Stream(ctx, req, opts...)
+	}
 	request := goMicroRequest{
 		request: req,
 		reqType: StreamRequest,
@@ -14,16 +14,6 @@
 
 package gomicro
 
-import (
-	"os"
-)
+import "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 
-type goMicroInnerEnabler struct {
-	enabled bool
-}
-
-func (g goMicroInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var goMicroEnabler = goMicroInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GOMICRO_ENABLED") != "false"}
+var goMicroEnabler = config.NewInstrumentationEnabler("gomicro")
@@ -136,6 +136,7 @@ func BuildGoMicroServerOtelInstrumenter() *instrumenter.PropagatingFromUpstreamI
 	urlExtractor := net.UrlAttrsExtractor[goMicroServerRequest, goMicroResponse, GoMicroServerAttrsGetter]{Getter: serverGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[goMicroServerRequest, goMicroResponse]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[goMicroServerRequest, goMicroResponse]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[goMicroServerRequest]{}).
+		SetSpanNameFilter(goMicroServerFilter).
 		AddOperationListeners(http.HttpServerMetrics("gomicro.server")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.GOMICRO_SERVER_SCOPE_NAME,
@@ -20,12 +20,15 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"go-micro.dev/v5/metadata"
 	"go-micro.dev/v5/server"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 )
 
+var goMicroServerFilter = utils.NewHttpServerFilter()
+
 var goMicroServerInstrument = BuildGoMicroServerOtelInstrumenter()
 
 //go:linkname ServeRequestOnEnter go-micro.dev/v5/server.ServeRequestOnEnter
@@ -39,6 +42,11 @@ func ServeRequestOnEnter(call api.CallContext, _ interface{}, ctx context.Contex
 		mda[strings.ToLower(key)] = val
 	}
 	ctx = propagators.Extract(ctx, propagation.MapCarrier(mda))
+	if goMicroServerFilter.FilterRequest(request.Method(), request.Service(), request.Endpoint()) {
+		// no span for the filtered request, but keep the upstream trace
+		call.SetParam(1, ctx)
+		return
+	}
 	req := goMicroServerRequest{
 		request: request,
 		ctx:     ctx,
@@ -125,6 +125,7 @@ func BuildGoMicroClientInstrumenter() *instrumenter.PropagatingToDownstreamInstr
 	networkExtractor := net.NetworkAttrsExtractor[goMicroRequest, goMicroResponse, goMicroHttpClientAttrsGetter]{Getter: clientGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpClientSpanStatusExtractor[goMicroRequest, goMicroResponse]{Getter: clientGetter}).SetSpanNameExtractor(&http.HttpClientSpanNameExtractor[goMicroRequest, goMicroResponse]{Getter: clientGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[goMicroRequest]{}).
+		SetSpanNameFilter(goMicroClientFilter).
 		AddOperationListeners(http.HttpClientMetrics("gomicro.client")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.GOMICRO_CLIENT_SCOPE_NAME,
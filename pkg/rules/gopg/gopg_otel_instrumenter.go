@@ -36,6 +36,10 @@ func (g gogpAttrsGetter) GetStatement(gopgRequest gopgRequest) string {
 	return gopgRequest.Statement
 }
 
+func (g gogpAttrsGetter) GetSanitizedStatement(gopgRequest gopgRequest) string {
+	return db.SanitizeSql("postgresql", gopgRequest.Statement)
+}
+
 func (g gogpAttrsGetter) GetCollection(_ gopgRequest) string {
 	// TBD: We need to implement retrieving the collection later.
 	return ""
@@ -17,25 +17,15 @@ package gopg
 import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
-	"os"
 	_ "unsafe"
 )
 
 var requestKey = "otel-request"
 
-type gopgInnerEnabler struct {
-	enabled bool
-}
-
-func (g gopgInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var gopgEnabler = gopgInnerEnabler{
-	enabled: os.Getenv("OTEL_INSTRUMENTATION_GOPG_ENABLED") != "false",
-}
+var gopgEnabler = config.NewInstrumentationEnabler("gopg")
 
 var gopgInstrumenter = BuildGopgInstrumenter()
 
@@ -17,11 +17,11 @@ package goredis
 import (
 	"context"
 	"net"
-	"os"
 	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/trace"
 
 	redis "github.com/redis/go-redis/v9"
@@ -29,15 +29,7 @@ import (
 
 var goRedisInstrumenter = BuildGoRedisOtelInstrumenter()
 
-type redisV9InnerEnabler struct {
-	enabled bool
-}
-
-func (r redisV9InnerEnabler) Enable() bool {
-	return r.enabled
-}
-
-var rv9Enabler = redisV9InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_REDISV9_ENABLED") != "false"}
+var rv9Enabler = config.NewInstrumentationEnabler("redisv9")
 
 var redisV9StartOptions = []trace.SpanStartOption{}
 
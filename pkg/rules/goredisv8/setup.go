@@ -3,26 +3,18 @@ package goredisv8
 import (
 	"context"
 	"errors"
-	"os"
 	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	redis "github.com/go-redis/redis/v8"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var redisv8Instrumenter = BuildRedisv8Instrumenter()
 
-type redisV8InnerEnabler struct {
-	enabled bool
-}
-
-func (g redisV8InnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var rv8Enabler = redisV8InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_REDISV8_ENABLED") != "false"}
+var rv8Enabler = config.NewInstrumentationEnabler("redisv8")
 
 var redisV8StartOptions = []trace.SpanStartOption{}
 
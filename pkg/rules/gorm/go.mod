@@ -6,14 +6,11 @@ replace github.com/alibaba/loongsuite-go-agent/pkg => ../../../pkg
 
 require (
 	github.com/alibaba/loongsuite-go-agent/pkg v0.0.0-00010101000000-000000000000
-	github.com/go-sql-driver/mysql v1.9.3
 	go.opentelemetry.io/otel/sdk v1.36.0
-	gorm.io/driver/mysql v1.1.3
 	gorm.io/gorm v1.22.0
 )
 
 require (
-	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/go-logr/logr v1.4.2 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/google/uuid v1.6.0 // indirect
@@ -34,7 +34,11 @@ func (g gormAttrsGetter) GetServerAddress(gormRequest gormRequest) string {
 }
 
 func (g gormAttrsGetter) GetStatement(gormRequest gormRequest) string {
-	return ""
+	return gormRequest.Statement
+}
+
+func (g gormAttrsGetter) GetSanitizedStatement(gormRequest gormRequest) string {
+	return db.SanitizeSql(gormRequest.System, gormRequest.Statement)
 }
 
 func (e gormAttrsGetter) GetCollection(gormRequest gormRequest) string {
@@ -51,7 +55,7 @@ func (g gormAttrsGetter) GetParameters(gormRequest gormRequest) []any {
 }
 
 func (g gormAttrsGetter) GetDbNamespace(gormRequest gormRequest) string {
-	return ""
+	return gormRequest.DbName
 }
 
 func (g gormAttrsGetter) GetBatchSize(gormRequest gormRequest) int {
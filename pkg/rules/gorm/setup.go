@@ -16,27 +16,19 @@ package gorm
 
 import (
 	"context"
-	"os"
+	"reflect"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
-	driver "github.com/go-sql-driver/mysql"
-	"gorm.io/driver/mysql"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"gorm.io/gorm"
 )
 
 var contextKey = "otel-context"
 var requestKey = "otel-request"
 
-type gormInnerEnabler struct {
-	enabled bool
-}
-
-func (g gormInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var gormEnabler = gormInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GORM_ENABLED") != "false"}
+var gormEnabler = config.NewInstrumentationEnabler("gorm")
 
 var gormInstrumenter = BuildGormInstrumenter()
 
@@ -48,6 +40,9 @@ func afterGormOpen(call api.CallContext, db *gorm.DB, err error) {
 	if err != nil || db == nil {
 		return
 	}
+	// Parse the DSN once the dialector is opened, callbacks then only read
+	// the info cached in the dialector
+	getDbInfo(db.Config.Dialector)
 	// add the callback
 	_ = db.Callback().Create().Before("gorm:create").Register("otel_create_create_span", beforeCallback("", "create"))
 	_ = db.Callback().Query().Before("gorm:query").Register("otel_create_query_span", beforeCallback("", "query"))
@@ -99,23 +94,67 @@ func afterCallback(endpoint string) func(db *gorm.DB) {
 		if !ok {
 			return
 		}
+		// The SQL is only built by the gorm callbacks running in between.
+		request.Statement = db.Statement.SQL.String()
 		gormInstrumenter.End(ctx, request, nil, db.Statement.Error)
 	}
 }
 
+// dialectorDrivers maps the names of gorm dialectors to the sql drivers whose
+// DSN format they accept
+var dialectorDrivers = map[string]string{
+	"mysql":      "mysql",
+	"postgres":   "postgres",
+	"sqlite":     "sqlite",
+	"sqlserver":  "sqlserver",
+	"clickhouse": "clickhouse",
+}
+
 func getDbInfo(dial gorm.Dialector) (string, string, string, string) {
-	// TODO: support other database
-	res, ok := dial.(*mysql.Dialector)
+	if dial == nil {
+		return "", "", "", ""
+	}
+	driverName, ok := dialectorDrivers[dial.Name()]
 	if !ok {
 		return "", "", "", ""
 	}
-	if cfg, ok := res.DbInfo.(*driver.Config); ok {
-		return cfg.DBName, cfg.Addr, "mysql", cfg.User
+	info := getDialectorDbInfo(dial, driverName)
+	return info.Database, info.Addr(), db.DbSystemOf(driverName), info.User
+}
+
+// getDialectorDbInfo parses the DSN of the dialector and caches the result in
+// the DbInfo field added to the dialector. Dialectors are accessed by
+// reflection, so that the rule does not pull every driver, in whatever
+// version, into the instrumented application.
+func getDialectorDbInfo(dial gorm.Dialector, driverName string) db.DSNInfo {
+	v := reflect.ValueOf(dial)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return db.DSNInfo{}
 	}
-	cfg, err := driver.ParseDSN(res.Config.DSN)
-	if err != nil {
-		return "", "", "", ""
+	v = v.Elem()
+	dbInfo := v.FieldByName("DbInfo")
+	if dbInfo.IsValid() {
+		if info, ok := dbInfo.Interface().(db.DSNInfo); ok {
+			return info
+		}
+	}
+	// The DSN is either a field of the dialector or of its embedded *Config,
+	// which may be nil
+	var dsn string
+	if field, ok := v.Type().FieldByName("DSN"); ok {
+		f, err := v.FieldByIndexErr(field.Index)
+		if err == nil && f.Kind() == reflect.String {
+			dsn = f.String()
+		}
+	}
+	if dsn == "" {
+		// Dialectors may be opened with an existing connection
+		return db.DSNInfo{}
+	}
+	info := db.LookupDSN(driverName, dsn)
+	if dbInfo.IsValid() && dbInfo.CanSet() &&
+		dbInfo.Kind() == reflect.Interface {
+		dbInfo.Set(reflect.ValueOf(info))
 	}
-	res.DbInfo = cfg
-	return cfg.DBName, cfg.Addr, "mysql", cfg.User
+	return info
 }
@@ -17,22 +17,14 @@ package golog
 import (
 	"context"
 	"log/slog"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type goSlogInnerEnabler struct {
-	enabled bool
-}
-
-func (g goSlogInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var goSlogEnabler = goSlogInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GOSLOG_ENABLED") != "false"}
+var goSlogEnabler = config.NewInstrumentationEnabler("goslog")
 
 //go:linkname goSlogWriteOnEnter log/slog.goSlogWriteOnEnter
 func goSlogWriteOnEnter(call api.CallContext, ce *slog.Logger, ctx context.Context, level slog.Level, msg string, args ...any) {
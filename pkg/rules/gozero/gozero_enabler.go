@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gozero
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var gozeroEnabler = config.NewInstrumentationEnabler("gozero")
+
+// nonRecordingSpan is handed to the tracing middlewares of go-zero in place of
+// their own spans, so that they neither end nor annotate the span in ctx.
+func nonRecordingSpan(ctx context.Context) trace.Span {
+	return trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx)))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gozero
+
+import (
+	"net/http"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/zeromicro/go-zero/rest/handler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var restServerSuppressor = instrumenter.NewSpanSuppressor(utils.HTTP_SERVER_KEY)
+
+// func TraceHandler(serviceName, path string, opts ...TraceOption) func(http.Handler) http.Handler
+//
+//go:linkname gozeroTraceHandlerOnEnter github.com/zeromicro/go-zero/rest/handler.gozeroTraceHandlerOnEnter
+func gozeroTraceHandlerOnEnter(call api.CallContext, serviceName, path string, opts ...handler.TraceOption) {
+	if !gozeroEnabler.Enable() {
+		return
+	}
+	call.SetData(path)
+}
+
+// The span of the go-zero trace middleware duplicates the one of the net/http
+// server, so the middleware is bypassed when the latter is present, and the
+// net/http span is named by the route template instead. Like the middleware,
+// the context of the span is still written to the response headers.
+//
+//go:linkname gozeroTraceHandlerOnExit github.com/zeromicro/go-zero/rest/handler.gozeroTraceHandlerOnExit
+func gozeroTraceHandlerOnExit(call api.CallContext, middleware func(http.Handler) http.Handler) {
+	path, ok := call.GetData().(string)
+	if !ok || middleware == nil {
+		return
+	}
+	call.SetReturnVal(0, func(next http.Handler) http.Handler {
+		traced := middleware(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !gozeroEnabler.Enable() || !restServerSuppressor.ShouldSuppress(r.Context(), trace.SpanKindServer) {
+				traced.ServeHTTP(w, r)
+				return
+			}
+			// the suppressor has checked that the span in the context is
+			// the one of the net/http server
+			if path != "" && r.URL != nil && path != r.URL.Path {
+				trace.SpanFromContext(r.Context()).SetName(path)
+			}
+			otel.GetTextMapPropagator().Inject(r.Context(), propagation.HeaderCarrier(w.Header()))
+			next.ServeHTTP(w, r)
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gozero
+
+import (
+	"context"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+var rpcServerSuppressor = instrumenter.NewSpanSuppressor(utils.RPC_SERVER_KEY)
+
+// The tracing interceptors of zRPC servers start their spans by startSpan,
+// which is skipped when the call is traced by the grpc server already.
+//
+// func startSpan(ctx context.Context, method string) (context.Context, trace.Span)
+//
+//go:linkname gozeroServerStartSpanOnEnter github.com/zeromicro/go-zero/zrpc/internal/serverinterceptors.gozeroServerStartSpanOnEnter
+func gozeroServerStartSpanOnEnter(call api.CallContext, ctx context.Context, method string) {
+	if !gozeroEnabler.Enable() {
+		return
+	}
+	if !rpcServerSuppressor.ShouldSuppress(ctx, trace.SpanKindServer) {
+		return
+	}
+	call.SetSkipCall(true)
+	call.SetData(ctx)
+}
+
+//go:linkname gozeroServerStartSpanOnExit github.com/zeromicro/go-zero/zrpc/internal/serverinterceptors.gozeroServerStartSpanOnExit
+func gozeroServerStartSpanOnExit(call api.CallContext, ctx context.Context, span trace.Span) {
+	skipStartSpan(call)
+}
+
+// grpcTracedKey marks the context of a zRPC client call whose connection is
+// traced by the stats handler of the grpc instrumentation.
+type grpcTracedKey struct{}
+
+// The grpc client span is started by the stats handler after the interceptors
+// of zRPC clients have run, so it can not be found by a suppressor. The tracing
+// interceptors mark the calls on connections carrying the stats handler, whose
+// grpc client spans are to come, and only the spans of these calls are skipped.
+//
+// func UnaryTracingInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error
+//
+//go:linkname gozeroUnaryTracingInterceptorOnEnter github.com/zeromicro/go-zero/zrpc/internal/clientinterceptors.gozeroUnaryTracingInterceptorOnEnter
+func gozeroUnaryTracingInterceptorOnEnter(call api.CallContext, ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) {
+	markGrpcTraced(call, ctx, cc)
+}
+
+// func StreamTracingInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error)
+//
+//go:linkname gozeroStreamTracingInterceptorOnEnter github.com/zeromicro/go-zero/zrpc/internal/clientinterceptors.gozeroStreamTracingInterceptorOnEnter
+func gozeroStreamTracingInterceptorOnEnter(call api.CallContext, ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) {
+	markGrpcTraced(call, ctx, cc)
+}
+
+func markGrpcTraced(call api.CallContext, ctx context.Context, cc *grpc.ClientConn) {
+	if !gozeroEnabler.Enable() || cc == nil || !cc.OtelTraced {
+		return
+	}
+	call.SetParam(0, context.WithValue(ctx, grpcTracedKey{}, true))
+}
+
+// func startSpan(ctx context.Context, method, target string) (context.Context, trace.Span)
+//
+//go:linkname gozeroClientStartSpanOnEnter github.com/zeromicro/go-zero/zrpc/internal/clientinterceptors.gozeroClientStartSpanOnEnter
+func gozeroClientStartSpanOnEnter(call api.CallContext, ctx context.Context, method, target string) {
+	if !gozeroEnabler.Enable() || ctx.Value(grpcTracedKey{}) == nil {
+		return
+	}
+	call.SetSkipCall(true)
+	call.SetData(ctx)
+}
+
+//go:linkname gozeroClientStartSpanOnExit github.com/zeromicro/go-zero/zrpc/internal/clientinterceptors.gozeroClientStartSpanOnExit
+func gozeroClientStartSpanOnExit(call api.CallContext, ctx context.Context, span trace.Span) {
+	skipStartSpan(call)
+}
+
+// skipStartSpan fills in the results of a skipped startSpan, which can only be
+// set once the call is about to return.
+func skipStartSpan(call api.CallContext) {
+	if !call.IsSkipCall() {
+		return
+	}
+	ctx, ok := call.GetData().(context.Context)
+	if !ok {
+		return
+	}
+	call.SetReturnVal(0, ctx)
+	call.SetReturnVal(1, nonRecordingSpan(ctx))
+}
@@ -33,14 +33,16 @@ func grpcClientOnEnter(call api.CallContext, ctx context.Context, target string,
 	opt = append(opt, h)
 	opt = append(opt, opts...)
 	call.SetParam(2, opt)
+	call.SetData(true)
 }
 
 //go:linkname grpcClientOnExit google.golang.org/grpc.grpcClientOnExit
 func grpcClientOnExit(call api.CallContext, cc *grpc.ClientConn, err error) {
-	if !grpcEnabler.Enable() {
-		return
+	// marks the connections whose calls are traced by the stats handler, for
+	// the instrumentations of frameworks that wrap grpc clients
+	if traced, ok := call.GetData().(bool); ok && traced && cc != nil {
+		cc.OtelTraced = true
 	}
-	return
 }
 
 type clientHandler struct {
@@ -34,7 +34,9 @@ func grpcNewClientOnEnter(call api.CallContext, target string, opts ...grpc.Dial
 
 //go:linkname grpcNewClientOnExit google.golang.org/grpc.grpcNewClientOnExit
 func grpcNewClientOnExit(call api.CallContext, cc *grpc.ClientConn, err error) {
-	return
+	if cc != nil {
+		cc.OtelTraced = true
+	}
 }
 
 type clientNewHandler struct {
@@ -16,10 +16,10 @@ package grpc
 
 import (
 	"fmt"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"os"
 	"strings"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
@@ -28,15 +28,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-type grpcInnerEnabler struct {
-	enabled bool
-}
-
-func (g grpcInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var grpcEnabler = grpcInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GRPC_ENABLED") != "false"}
+var grpcEnabler = config.NewInstrumentationEnabler("grpc")
 
 type grpcAttrsGetter struct {
 }
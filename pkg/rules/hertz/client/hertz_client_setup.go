@@ -16,28 +16,29 @@ package client
 
 import (
 	"context"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/cloudwego/hertz/pkg/app/client"
 	"github.com/cloudwego/hertz/pkg/protocol"
+	"go.opentelemetry.io/otel"
 )
 
-type hertzClientInnerEnabler struct {
-	enabled bool
-}
-
-func (h hertzClientInnerEnabler) Enable() bool {
-	return h.enabled
-}
+var hertzClientEnabler = config.NewInstrumentationEnabler("hertz")
 
-var hertzClientEnabler = hertzClientInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_HERTZ_ENABLED") != "false"}
+var hertzClientFilter = utils.NewHttpClientFilter()
 
 var hertzClientInstrumenter = BuildHertzClientInstrumenter()
 
 func otelClientMiddleware(next client.Endpoint) client.Endpoint {
 	return func(ctx context.Context, req *protocol.Request, resp *protocol.Response) (err error) {
+		if hertzClientFilter.FilterRequest(string(req.Method()), string(req.Host()), string(req.URI().Path())) {
+			// no span for the filtered request, but keep the trace going downstream
+			otel.GetTextMapPropagator().Inject(ctx, hertzTextMapCarrier{req})
+			return next(ctx, req, resp)
+		}
 		ctx = hertzClientInstrumenter.Start(ctx, req)
 		err = next(ctx, req, resp)
 		if err != nil {
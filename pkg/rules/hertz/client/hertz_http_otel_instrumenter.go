@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cloudwego/hertz/pkg/protocol"
 
@@ -44,11 +45,13 @@ func (h hertzHttpClientAttrsGetter) GetRequestMethod(request *protocol.Request)
 }
 
 func (h hertzHttpClientAttrsGetter) GetHttpRequestHeader(request *protocol.Request, name string) []string {
-	keys := make([]string, 0)
-	request.Header.VisitAllCustomHeader(func(key, value []byte) {
-		keys = append(keys, string(key))
+	values := make([]string, 0)
+	request.Header.VisitAll(func(key, value []byte) {
+		if strings.EqualFold(string(key), name) {
+			values = append(values, string(value))
+		}
 	})
-	return keys
+	return values
 }
 
 func (h hertzHttpClientAttrsGetter) GetHttpResponseStatusCode(request *protocol.Request, response *protocol.Response, err error) int {
@@ -56,11 +59,27 @@ func (h hertzHttpClientAttrsGetter) GetHttpResponseStatusCode(request *protocol.
 }
 
 func (h hertzHttpClientAttrsGetter) GetHttpResponseHeader(request *protocol.Request, response *protocol.Response, name string) []string {
-	keys := make([]string, 0)
+	values := make([]string, 0)
 	response.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
+		if strings.EqualFold(string(key), name) {
+			values = append(values, string(value))
+		}
 	})
-	return keys
+	return values
+}
+
+func (h hertzHttpClientAttrsGetter) GetHttpRequestBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if request.IsBodyStream() {
+		return -1
+	}
+	return int64(len(request.Body()))
+}
+
+func (h hertzHttpClientAttrsGetter) GetHttpResponseBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if response == nil || response.IsBodyStream() {
+		return -1
+	}
+	return int64(len(response.Body()))
 }
 
 func (h hertzHttpClientAttrsGetter) GetErrorType(request *protocol.Request, response *protocol.Response, err error) string {
@@ -154,6 +173,7 @@ func BuildHertzClientInstrumenter() *instrumenter.PropagatingToDownstreamInstrum
 	networkExtractor := net.NetworkAttrsExtractor[*protocol.Request, *protocol.Response, hertzHttpClientAttrsGetter]{Getter: clientGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpClientSpanStatusExtractor[*protocol.Request, *protocol.Response]{Getter: clientGetter}).SetSpanNameExtractor(&http.HttpClientSpanNameExtractor[*protocol.Request, *protocol.Response]{Getter: clientGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[*protocol.Request]{}).
+		SetSpanNameFilter(hertzClientFilter).
 		AddOperationListeners(http.HttpClientMetrics("hertz.client")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.HERTZ_HTTP_CLIENT_SCOPE_NAME,
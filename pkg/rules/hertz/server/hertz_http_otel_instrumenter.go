@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cloudwego/hertz/pkg/protocol"
 
@@ -44,11 +45,13 @@ func (n hertzHttpServerAttrsGetter) GetRequestMethod(request *protocol.Request)
 }
 
 func (n hertzHttpServerAttrsGetter) GetHttpRequestHeader(request *protocol.Request, name string) []string {
-	keys := make([]string, 0)
+	values := make([]string, 0)
 	request.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
+		if strings.EqualFold(string(key), name) {
+			values = append(values, string(value))
+		}
 	})
-	return keys
+	return values
 }
 
 func (n hertzHttpServerAttrsGetter) GetHttpResponseStatusCode(request *protocol.Request, response *protocol.Response, err error) int {
@@ -56,11 +59,27 @@ func (n hertzHttpServerAttrsGetter) GetHttpResponseStatusCode(request *protocol.
 }
 
 func (n hertzHttpServerAttrsGetter) GetHttpResponseHeader(request *protocol.Request, response *protocol.Response, name string) []string {
-	keys := make([]string, 0)
+	values := make([]string, 0)
 	response.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
+		if strings.EqualFold(string(key), name) {
+			values = append(values, string(value))
+		}
 	})
-	return keys
+	return values
+}
+
+func (n hertzHttpServerAttrsGetter) GetHttpRequestBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if request.IsBodyStream() {
+		return -1
+	}
+	return int64(len(request.Body()))
+}
+
+func (n hertzHttpServerAttrsGetter) GetHttpResponseBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if response == nil || response.IsBodyStream() {
+		return -1
+	}
+	return int64(len(response.Body()))
 }
 
 func (n hertzHttpServerAttrsGetter) GetErrorType(request *protocol.Request, response *protocol.Response, err error) string {
@@ -163,6 +182,7 @@ func BuildHertzServerInstrumenter() *instrumenter.PropagatingFromUpstreamInstrum
 	urlExtractor := net.UrlAttrsExtractor[*protocol.Request, *protocol.Response, hertzHttpServerAttrsGetter]{Getter: serverGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[*protocol.Request, *protocol.Response]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[*protocol.Request, *protocol.Response]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[*protocol.Request]{}).
+		SetSpanNameFilter(hertzServerFilter).
 		AddOperationListeners(http.HttpServerMetrics("hertz.server")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.HERTZ_HTTP_SERVER_SCOPE_NAME,
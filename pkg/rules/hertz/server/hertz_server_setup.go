@@ -16,10 +16,11 @@ package server
 
 import (
 	"context"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	instconfig "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/config"
@@ -27,15 +28,9 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type hertzServerInnerEnabler struct {
-	enabled bool
-}
-
-func (h hertzServerInnerEnabler) Enable() bool {
-	return h.enabled
-}
+var hertzServerEnabler = instconfig.NewInstrumentationEnabler("hertz")
 
-var hertzServerEnabler = hertzServerInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_HERTZ_ENABLED") != "false"}
+var hertzServerFilter = utils.NewHttpServerFilter()
 
 var hertzInstrumenter = BuildHertzServerInstrumenter()
 
@@ -55,6 +50,9 @@ func (m *hertzOpentelemetryTracer) Finish(ctx context.Context, c *app.RequestCon
 		s := start.Time()
 		e := end.Time()
 		req, resp := &c.Request, &c.Response
+		if hertzServerFilter.FilterRequest(string(req.Method()), string(req.Host()), string(req.URI().Path())) {
+			return
+		}
 		insctx := hertzInstrumenter.StartWithTime(ctx, req, s)
 		lcs := trace.LocalRootSpanFromGLS()
 		if lcs != nil && c.FullPath() != "" {
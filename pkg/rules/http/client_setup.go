@@ -22,10 +22,11 @@ import (
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
-// TODO: use a interface to filter
-var netHttpFilter = utils.DefaultUrlFilter{}
+var netHttpClientFilter = utils.NewHttpClientFilter()
 
 var netHttpClientInstrumenter = BuildNetHttpClientOtelInstrumenter()
 
@@ -40,7 +41,9 @@ func clientOnEnter(call api.CallContext, t *http.Transport, req *http.Request) {
 	if strings.HasPrefix(req.Header.Get("user-agent"), otelExporterPrefix) {
 		return
 	}
-	if netHttpFilter.FilterUrl(req.URL) {
+	if netHttpClientFilter.FilterRequest(req.Method, req.URL.Host, req.URL.Path) {
+		// no span for the filtered request, but keep the trace going downstream
+		otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
 		return
 	}
 	netHttpRequest := &netHttpRequest{
@@ -52,10 +55,12 @@ func clientOnEnter(call api.CallContext, t *http.Transport, req *http.Request) {
 	}
 	netHttpRequest.version = getProtocolVersion(req.ProtoMajor, req.ProtoMinor)
 	ctx := netHttpClientInstrumenter.Start(req.Context(), netHttpRequest)
-	req = req.WithContext(ctx)
+	traceCtx, traceState := withClientTrace(ctx)
+	req = req.WithContext(traceCtx)
 	call.SetParam(1, req)
-	data := make(map[string]interface{}, 1)
+	data := make(map[string]interface{}, 2)
 	data["ctx"] = ctx
+	data["trace"] = traceState
 	call.SetData(data)
 	return
 }
@@ -70,21 +75,28 @@ func clientOnExit(call api.CallContext, res *http.Response, err error) {
 		return
 	}
 	ctx := data["ctx"].(context.Context)
+	traceState, _ := data["trace"].(*clientTraceState)
+	peerAddress, peerPort := traceState.peer()
 	if res != nil {
 		netHttpClientInstrumenter.End(ctx, &netHttpRequest{
-			method:  res.Request.Method,
-			url:     res.Request.URL,
-			header:  res.Request.Header,
-			version: getProtocolVersion(res.Request.ProtoMajor, res.Request.ProtoMinor),
-			host:    res.Request.Host,
-			isTls:   res.Request.TLS != nil,
+			method:      res.Request.Method,
+			url:         res.Request.URL,
+			header:      res.Request.Header,
+			version:     getProtocolVersion(res.Request.ProtoMajor, res.Request.ProtoMinor),
+			host:        res.Request.Host,
+			isTls:       res.Request.TLS != nil,
+			bodySize:    clientRequestBodySize(res.Request),
+			peerAddress: peerAddress,
+			peerPort:    peerPort,
 		}, &netHttpResponse{
 			statusCode: res.StatusCode,
 			header:     res.Header,
+			bodySize:   res.ContentLength,
 		}, err)
 	} else {
-		netHttpClientInstrumenter.End(ctx, &netHttpRequest{}, &netHttpResponse{
+		netHttpClientInstrumenter.End(ctx, &netHttpRequest{bodySize: -1, peerAddress: peerAddress, peerPort: peerPort}, &netHttpResponse{
 			statusCode: 500,
+			bodySize:   -1,
 		}, err)
 	}
 }
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	clientTraceOff    = "off"
+	clientTraceEvents = "events"
+	clientTraceSpans  = "spans"
+)
+
+// The client span always records the address of the connection the request
+// was sent on. "events" additionally adds span events for the DNS lookup,
+// connect, TLS handshake, connection acquisition and first response byte,
+// "spans" records the DNS lookup, connect and TLS handshake as child spans
+// instead. Unknown values behave like "off".
+var clientTraceMode = config.String("nethttp", "client.trace", clientTraceOff,
+	"How to trace the network phases of net/http client requests: off, events or spans.")
+
+var netHttpClientPhaseInstrumenter = buildNetHttpClientPhaseInstrumenter()
+
+const (
+	phaseDNS     = "http.dns"
+	phaseConnect = "http.connect"
+	phaseTLS     = "http.tls"
+)
+
+// netHttpClientPhase is a network phase of a client request, it is traced
+// once the phase is done so the attributes are complete.
+type netHttpClientPhase struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+type netHttpClientPhaseSpanNameExtractor struct{}
+
+func (e netHttpClientPhaseSpanNameExtractor) Extract(request netHttpClientPhase) string {
+	return request.name
+}
+
+type netHttpClientPhaseAttrsExtractor struct{}
+
+func (e netHttpClientPhaseAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request netHttpClientPhase) ([]attribute.KeyValue, context.Context) {
+	return append(attributes, request.attrs...), parentContext
+}
+
+func (e netHttpClientPhaseAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request netHttpClientPhase, response any, err error) ([]attribute.KeyValue, context.Context) {
+	if err != nil {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+	return attributes, ctx
+}
+
+func buildNetHttpClientPhaseInstrumenter() instrumenter.Instrumenter[netHttpClientPhase, any] {
+	builder := instrumenter.Builder[netHttpClientPhase, any]{}
+	return builder.Init().SetSpanNameExtractor(netHttpClientPhaseSpanNameExtractor{}).
+		SetSpanKindExtractor(&instrumenter.AlwaysInternalExtractor[netHttpClientPhase]{}).
+		AddAttributesExtractor(netHttpClientPhaseAttrsExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NET_HTTP_CLIENT_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		BuildInstrumenter()
+}
+
+// clientTraceState collects what httptrace reports during one RoundTrip.
+// Dials run on their own goroutines and may race with each other, e.g. when
+// both IPv4 and IPv6 addresses are tried, hence the lock.
+type clientTraceState struct {
+	ctx  context.Context
+	mode string
+
+	mu            sync.Mutex
+	peerAddr      string
+	peerPort      int
+	dnsStart      time.Time
+	connectStarts map[string]time.Time
+	tlsStart      time.Time
+}
+
+// withClientTrace attaches a httptrace.ClientTrace reporting to the client
+// span in ctx, it composes with any ClientTrace set by the application.
+func withClientTrace(ctx context.Context) (context.Context, *clientTraceState) {
+	s := &clientTraceState{ctx: ctx, mode: clientTraceMode.Get()}
+	ct := &httptrace.ClientTrace{GotConn: s.onGotConn}
+	if s.mode == clientTraceEvents || s.mode == clientTraceSpans {
+		ct.DNSStart = s.onDNSStart
+		ct.DNSDone = s.onDNSDone
+		ct.ConnectStart = s.onConnectStart
+		ct.ConnectDone = s.onConnectDone
+		ct.TLSHandshakeStart = s.onTLSHandshakeStart
+		ct.TLSHandshakeDone = s.onTLSHandshakeDone
+		ct.GotFirstResponseByte = s.onGotFirstResponseByte
+	}
+	return httptrace.WithClientTrace(ctx, ct), s
+}
+
+// peer returns the address of the connection the request was sent on.
+func (s *clientTraceState) peer() (string, int) {
+	if s == nil {
+		return "", 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.peerAddr, s.peerPort
+}
+
+func (s *clientTraceState) event(name string, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(s.ctx).AddEvent(name, trace.WithAttributes(attrs...))
+}
+
+func (s *clientTraceState) phaseStart(name string, attrs ...attribute.KeyValue) {
+	if s.mode == clientTraceEvents {
+		s.event(name+".start", attrs...)
+	}
+}
+
+func (s *clientTraceState) phaseDone(name string, start time.Time, err error, attrs ...attribute.KeyValue) {
+	switch s.mode {
+	case clientTraceEvents:
+		if err != nil {
+			attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+		}
+		s.event(name+".done", attrs...)
+	case clientTraceSpans:
+		if start.IsZero() {
+			return
+		}
+		netHttpClientPhaseInstrumenter.StartAndEnd(s.ctx, netHttpClientPhase{name: name, attrs: attrs}, nil, err, start, time.Now())
+	}
+}
+
+func (s *clientTraceState) onDNSStart(info httptrace.DNSStartInfo) {
+	s.mu.Lock()
+	s.dnsStart = time.Now()
+	s.mu.Unlock()
+	s.phaseStart(phaseDNS, semconv.ServerAddress(info.Host))
+}
+
+func (s *clientTraceState) onDNSDone(info httptrace.DNSDoneInfo) {
+	s.mu.Lock()
+	start := s.dnsStart
+	s.mu.Unlock()
+	addrs := make([]string, 0, len(info.Addrs))
+	for _, addr := range info.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+	s.phaseDone(phaseDNS, start, info.Err, attribute.StringSlice("http.dns.addrs", addrs))
+}
+
+func (s *clientTraceState) onConnectStart(network, addr string) {
+	s.mu.Lock()
+	if s.connectStarts == nil {
+		s.connectStarts = make(map[string]time.Time, 1)
+	}
+	s.connectStarts[network+" "+addr] = time.Now()
+	s.mu.Unlock()
+	s.phaseStart(phaseConnect, connectAttrs(network, addr)...)
+}
+
+func (s *clientTraceState) onConnectDone(network, addr string, err error) {
+	s.mu.Lock()
+	start := s.connectStarts[network+" "+addr]
+	delete(s.connectStarts, network+" "+addr)
+	s.mu.Unlock()
+	s.phaseDone(phaseConnect, start, err, connectAttrs(network, addr)...)
+}
+
+func (s *clientTraceState) onTLSHandshakeStart() {
+	s.mu.Lock()
+	s.tlsStart = time.Now()
+	s.mu.Unlock()
+	s.phaseStart(phaseTLS)
+}
+
+func (s *clientTraceState) onTLSHandshakeDone(state tls.ConnectionState, err error) {
+	s.mu.Lock()
+	start := s.tlsStart
+	s.mu.Unlock()
+	var attrs []attribute.KeyValue
+	if err == nil {
+		attrs = append(attrs, semconv.TLSProtocolNameTLS,
+			semconv.TLSProtocolVersion(strings.TrimPrefix(tls.VersionName(state.Version), "TLS ")),
+			semconv.TLSResumed(state.DidResume))
+	}
+	s.phaseDone(phaseTLS, start, err, attrs...)
+}
+
+func (s *clientTraceState) onGotConn(info httptrace.GotConnInfo) {
+	if info.Conn != nil {
+		if addr, port := splitHostPort(info.Conn.RemoteAddr().String()); addr != "" {
+			s.mu.Lock()
+			s.peerAddr, s.peerPort = addr, port
+			s.mu.Unlock()
+		}
+	}
+	if s.mode != clientTraceEvents && s.mode != clientTraceSpans {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Bool("http.conn.reused", info.Reused),
+		attribute.Bool("http.conn.was_idle", info.WasIdle),
+	}
+	if info.WasIdle {
+		attrs = append(attrs, attribute.Int64("http.conn.idle_time_ms", info.IdleTime.Milliseconds()))
+	}
+	s.event("http.conn.acquired", attrs...)
+}
+
+func (s *clientTraceState) onGotFirstResponseByte() {
+	s.event("http.first_response_byte")
+}
+
+func connectAttrs(network, addr string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.NetworkTransportKey.String(strings.TrimRight(network, "46"))}
+	if host, port := splitHostPort(addr); host != "" {
+		attrs = append(attrs, semconv.NetworkPeerAddress(host), semconv.NetworkPeerPort(port))
+	}
+	return attrs
+}
+
+// networkType tells the IP version of the peer address, ipv4 if unknown.
+func networkType(addr string) string {
+	if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+func splitHostPort(addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0
+	}
+	port, _ := strconv.Atoi(portStr)
+	return host, port
+}
@@ -15,6 +15,7 @@
 package http
 
 import (
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -27,11 +28,42 @@ type netHttpRequest struct {
 	isTls   bool
 	header  http.Header
 	version string
+	// bodySize is the size of the request body, -1 means unknown
+	bodySize int64
+	// peerAddress and peerPort are the remote end of the connection the
+	// request was sent on, only known to the client
+	peerAddress string
+	peerPort    int
 }
 
 type netHttpResponse struct {
 	statusCode int
 	header     http.Header
+	// bodySize is the size of the response body, -1 means unknown
+	bodySize int64
+}
+
+// bodyWrapper counts the bytes read from the request body by the handler, it
+// measures the request body size when the Content-Length is unknown, so the
+// unread remains of such bodies are not counted.
+type bodyWrapper struct {
+	io.ReadCloser
+	read int64
+}
+
+func (b *bodyWrapper) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	return n, err
+}
+
+// clientRequestBodySize returns the size of the outgoing request body, a zero
+// ContentLength with a body means unknown.
+func clientRequestBodySize(req *http.Request) int64 {
+	if req.ContentLength == 0 && req.Body != nil && req.Body != http.NoBody {
+		return -1
+	}
+	return req.ContentLength
 }
 
 func getProtocolVersion(majorVersion, minorVersion int) string {
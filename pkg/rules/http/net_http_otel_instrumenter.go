@@ -15,9 +15,9 @@
 package http
 
 import (
-	"os"
 	"strconv"
 
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -29,15 +29,7 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 )
 
-type netHttpInnerEnabler struct {
-	enabled bool
-}
-
-func (n netHttpInnerEnabler) Enable() bool {
-	return n.enabled
-}
-
-var netHttpEnabler = netHttpInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_NETHTTP_ENABLED") != "false"}
+var netHttpEnabler = config.NewInstrumentationEnabler("nethttp")
 
 var emptyHttpResponse = netHttpResponse{}
 
@@ -60,13 +52,21 @@ func (n netHttpClientAttrsGetter) GetHttpResponseHeader(request *netHttpRequest,
 	return response.header.Values(name)
 }
 
+func (n netHttpClientAttrsGetter) GetHttpRequestBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return request.bodySize
+}
+
+func (n netHttpClientAttrsGetter) GetHttpResponseBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return response.bodySize
+}
+
 func (n netHttpClientAttrsGetter) GetErrorType(request *netHttpRequest, response *netHttpResponse, err error) string {
 	// TODO return status code as error type
 	return ""
 }
 
 func (n netHttpClientAttrsGetter) GetNetworkType(request *netHttpRequest, response *netHttpResponse) string {
-	return "ipv4"
+	return networkType(request.peerAddress)
 }
 
 func (n netHttpClientAttrsGetter) GetNetworkTransport(request *netHttpRequest, response *netHttpResponse) string {
@@ -94,18 +94,11 @@ func (n netHttpClientAttrsGetter) GetNetworkLocalPort(request *netHttpRequest, r
 }
 
 func (n netHttpClientAttrsGetter) GetNetworkPeerInetAddress(request *netHttpRequest, response *netHttpResponse) string {
-	return request.host
+	return request.peerAddress
 }
 
 func (n netHttpClientAttrsGetter) GetNetworkPeerPort(request *netHttpRequest, response *netHttpResponse) int {
-	if request.url == nil {
-		return 0
-	}
-	port, err := strconv.Atoi(request.url.Port())
-	if err != nil {
-		return 0
-	}
-	return port
+	return request.peerPort
 }
 
 func (n netHttpClientAttrsGetter) GetUrlFull(request *netHttpRequest) string {
@@ -146,6 +139,14 @@ func (n netHttpServerAttrsGetter) GetHttpResponseHeader(request *netHttpRequest,
 	return response.header.Values(name)
 }
 
+func (n netHttpServerAttrsGetter) GetHttpRequestBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return request.bodySize
+}
+
+func (n netHttpServerAttrsGetter) GetHttpResponseBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return response.bodySize
+}
+
 func (n netHttpServerAttrsGetter) GetErrorType(request *netHttpRequest, response *netHttpResponse, err error) string {
 	// TODO return status code as error type
 	return ""
@@ -216,6 +217,7 @@ func BuildNetHttpClientOtelInstrumenter() *instrumenter.PropagatingToDownstreamI
 	networkExtractor := net.NetworkAttrsExtractor[*netHttpRequest, *netHttpResponse, net.NetworkAttrsGetter[*netHttpRequest, *netHttpResponse]]{Getter: clientGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpClientSpanStatusExtractor[*netHttpRequest, *netHttpResponse]{Getter: clientGetter}).SetSpanNameExtractor(&http.HttpClientSpanNameExtractor[*netHttpRequest, *netHttpResponse]{Getter: clientGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[*netHttpRequest]{}).
+		SetSpanNameFilter(netHttpClientFilter).
 		AddOperationListeners(http.HttpClientMetrics("net.http.client")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.NET_HTTP_CLIENT_SCOPE_NAME,
@@ -237,6 +239,7 @@ func BuildNetHttpServerOtelInstrumenter() *instrumenter.PropagatingFromUpstreamI
 	urlExtractor := net.UrlAttrsExtractor[*netHttpRequest, *netHttpResponse, net.UrlAttrsGetter[*netHttpRequest]]{Getter: serverGetter}
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[*netHttpRequest, *netHttpResponse]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[*netHttpRequest, *netHttpResponse]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[*netHttpRequest]{}).
+		SetSpanNameFilter(netHttpServerFilter).
 		AddOperationListeners(http.HttpServerMetrics("net.http.server")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.NET_HTTP_SERVER_SCOPE_NAME,
@@ -23,8 +23,15 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var netHttpServerFilter = utils.NewHttpServerFilter()
+
 var netHttpServerInstrumenter = BuildNetHttpServerOtelInstrumenter()
 
 //go:linkname serverOnEnter net/http.serverOnEnter
@@ -32,7 +39,12 @@ func serverOnEnter(call api.CallContext, _ interface{}, w http.ResponseWriter, r
 	if !netHttpEnabler.Enable() {
 		return
 	}
-	if netHttpFilter.FilterUrl(r.URL) {
+	if netHttpServerFilter.FilterRequest(r.Method, r.Host, r.URL.Path) {
+		// no span for the filtered request, but keep the upstream trace for
+		// the handler, whether it reads it from the request or not
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		call.SetParam(2, r.WithContext(ctx))
+		call.SetData(sdktrace.SpanContextToGLS(trace.SpanContextFromContext(ctx)))
 		return
 	}
 	request := &netHttpRequest{
@@ -48,7 +60,15 @@ func serverOnEnter(call api.CallContext, _ interface{}, w http.ResponseWriter, r
 		x1 := &writerWrapper{ResponseWriter: x, statusCode: http.StatusOK}
 		call.SetParam(1, x1)
 	}
-	data := make(map[string]interface{}, 2)
+	data := make(map[string]interface{}, 3)
+	// The Content-Length is the request body size when it is known, otherwise
+	// e.g. for chunked requests, the bytes read by the handler are counted
+	request.bodySize = r.ContentLength
+	if r.ContentLength < 0 && r.Body != nil && r.Body != http.NoBody {
+		body := &bodyWrapper{ReadCloser: r.Body}
+		r.Body = body
+		data["body"] = body
+	}
 	data["ctx"] = ctx
 	data["request"] = request
 	call.SetData(data)
@@ -57,6 +77,10 @@ func serverOnEnter(call api.CallContext, _ interface{}, w http.ResponseWriter, r
 
 //go:linkname serverOnExit net/http.serverOnExit
 func serverOnExit(call api.CallContext) {
+	if removeUpstream, ok := call.GetData().(func()); ok {
+		removeUpstream()
+		return
+	}
 	if !netHttpEnabler.Enable() {
 		return
 	}
@@ -69,10 +93,15 @@ func serverOnExit(call api.CallContext) {
 	if !ok {
 		return
 	}
+	if body, ok := data["body"].(*bodyWrapper); ok {
+		request.bodySize = body.read
+	}
 	if p, ok := call.GetParam(1).(http.ResponseWriter); ok {
 		if w1, ok := p.(*writerWrapper); ok {
 			netHttpServerInstrumenter.End(ctx, request, &netHttpResponse{
 				statusCode: w1.statusCode,
+				header:     w1.Header(),
+				bodySize:   w1.written,
 			}, nil)
 		}
 	}
@@ -83,6 +112,13 @@ func serverOnExit(call api.CallContext) {
 type writerWrapper struct {
 	http.ResponseWriter
 	statusCode int
+	written    int64
+}
+
+func (w *writerWrapper) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
 }
 
 func (w *writerWrapper) WriteHeader(statusCode int) {
@@ -15,21 +15,12 @@
 package k8s_client_go
 
 import (
-	"os"
 	"time"
-)
-
-type K8sClientGoInnerEnabler struct {
-	enabled bool
-}
 
-var k8sEnabler = K8sClientGoInnerEnabler{
-	enabled: os.Getenv("OTEL_INSTRUMENTATION_K8S_CLIENT_GO_ENABLED") != "false",
-}
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+)
 
-func (k K8sClientGoInnerEnabler) Enable() bool {
-	return k.enabled
-}
+var k8sEnabler = config.NewInstrumentationEnabler("k8s-client-go")
 
 type k8sEventInfo struct {
 	eventType       string
@@ -16,25 +16,17 @@ package kitex
 
 import (
 	"fmt"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"os"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 )
 
-type kitexInnerEnabler struct {
-	enabled bool
-}
-
-func (k kitexInnerEnabler) Enable() bool {
-	return k.enabled
-}
-
-var kitexEnabler = kitexInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_KITEX_ENABLED") != "false"}
+var kitexEnabler = config.NewInstrumentationEnabler("kitex")
 
 type kitexAttrsGetter struct{}
 
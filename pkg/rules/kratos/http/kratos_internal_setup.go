@@ -16,10 +16,10 @@ package http
 
 import (
 	"context"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	kt "github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -27,13 +27,14 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
-const OTEL_INSTRUMENTATION_KRATOS_EXPERIMENTAL_SPAN_ENABLE = "OTEL_INSTRUMENTATION_KRATOS_EXPERIMENTAL_SPAN_ENABLE"
+var kratosExperimentalSpanEnabled = config.Bool("kratos", "experimental.span.enable", false,
+	"Enable the capture of experimental kratos span attributes.")
 
 var kratosInternalInstrument = BuildKratosInternalInstrumenter()
 
 //go:linkname kratosNewHTTPServiceOnEnter github.com/go-kratos/kratos/v2/transport/http.kratosNewHTTPServiceOnEnter
 func kratosNewHTTPServiceOnEnter(call api.CallContext, opts ...http.ServerOption) {
-	if os.Getenv(OTEL_INSTRUMENTATION_KRATOS_EXPERIMENTAL_SPAN_ENABLE) != "true" {
+	if !kratosExperimentalSpanEnabled.Get() {
 		return
 	}
 	opts = append(opts, AddHTTPMiddleware(ServerTracingMiddleWare()))
@@ -14,9 +14,7 @@
 
 package langchain
 
-import (
-	"os"
-)
+import "github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 
 const (
 	MLlmGenerateSingle = "llmGenerateSingle"
@@ -27,14 +25,6 @@ const (
 	MRelevantDoc       = "relevantDocuments"
 )
 
-type langChainInnerEnabler struct {
-	enabled bool
-}
-
-func (l langChainInnerEnabler) Enable() bool {
-	return l.enabled
-}
-
-var langChainEnabler = langChainInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_LANGCHAIN_ENABLED") != "false"}
+var langChainEnabler = config.NewInstrumentationEnabler("langchain")
 
 var langChainCommonInstrument = BuildCommonLangchainOtelInstrumenter()
@@ -18,20 +18,12 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/sdk/trace"
-	"os"
 )
 
-type logrusInnerEnabler struct {
-	enabled bool
-}
-
-func (l logrusInnerEnabler) Enable() bool {
-	return l.enabled
-}
-
-var logrusEnabler = logrusInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_LOGRUS_ENABLED") != "false"}
+var logrusEnabler = config.NewInstrumentationEnabler("logrus")
 
 //go:linkname withFieldOnExit github.com/sirupsen/logrus.withFieldOnExit
 func withFieldOnExit(call api.CallContext, e *logrus.Entry) {
@@ -17,89 +17,148 @@ package mongo
 import (
 	"context"
 	"errors"
-	"fmt"
-	"os"
 	"strings"
 	"sync"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var mongoInstrumenter = BuildMongoOtelInstrumenter()
+var mongoInstrumenter = db.BuildMongoInstrumenter(utils.MONGO_SCOPE_NAME)
 
-type mongoInnerEnabler struct {
-	enabled bool
-}
+var mongoEnabler = config.NewInstrumentationEnabler("mongo")
 
-func (m mongoInnerEnabler) Enable() bool {
-	return m.enabled
+// batchFields are the fields holding the operations of batched write commands
+var batchFields = map[string]string{
+	"insert": "documents",
+	"update": "updates",
+	"delete": "deletes",
 }
 
-var mongoEnabler = mongoInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_MONGO_ENABLED") != "false"}
+type commandSpan struct {
+	ctx     context.Context
+	request db.MongoRequest
+}
 
 //go:linkname mongoOnEnter go.mongodb.org/mongo-driver/mongo.mongoOnEnter
 func mongoOnEnter(call api.CallContext, opts ...*options.ClientOptions) {
 	if !mongoEnabler.Enable() {
 		return
 	}
-	syncMap := sync.Map{}
+	// Options are merged in order and later fields overwrite earlier ones,
+	// so the monitor appended last wraps whatever monitor is configured
+	var hosts []string
+	var configuredMonitor *event.CommandMonitor
 	for _, opt := range opts {
-		hosts := opt.Hosts
-		hostLength := len(hosts)
-		if hostLength == 0 {
+		if opt == nil {
 			continue
 		}
-		configuredMonitor := opt.Monitor
-		var host string
-		opt.Monitor = &event.CommandMonitor{
-			Started: func(ctx context.Context, startedEvent *event.CommandStartedEvent) {
-				if configuredMonitor != nil {
-					configuredMonitor.Started(ctx, startedEvent)
-				}
-				host = hosts[0]
-				if hostLength > 1 {
-					if infoSplit := strings.Index(startedEvent.ConnectionID, "["); infoSplit > 0 && strings.HasSuffix(startedEvent.ConnectionID, "]") {
-						host = startedEvent.ConnectionID[0:infoSplit]
-					}
-				}
-				mongoRequest := mongoRequest{
-					CommandName: startedEvent.CommandName,
-					Host:        host,
-				}
-				newCtx := mongoInstrumenter.Start(ctx, mongoRequest)
-				syncMap.Store(fmt.Sprintf("%d", startedEvent.RequestID), newCtx)
-			},
-			Succeeded: func(ctx context.Context, succeededEvent *event.CommandSucceededEvent) {
-				if configuredMonitor != nil {
-					configuredMonitor.Succeeded(ctx, succeededEvent)
-				}
-				if newCtx, ok := syncMap.LoadAndDelete(fmt.Sprintf("%d", succeededEvent.RequestID)); ok && newCtx != nil {
-					newContext, ok := newCtx.(context.Context)
-					if ok {
-						mongoInstrumenter.End(newContext, mongoRequest{
-							CommandName: succeededEvent.CommandName,
-							Host:        host,
-						}, nil, nil)
-					}
-				}
-			},
-			Failed: func(ctx context.Context, failedEvent *event.CommandFailedEvent) {
-				if configuredMonitor != nil {
-					configuredMonitor.Failed(ctx, failedEvent)
-				}
-				if newCtx, ok := syncMap.LoadAndDelete(fmt.Sprintf("%d", failedEvent.RequestID)); ok && newCtx != nil {
-					newContext, ok := newCtx.(context.Context)
-					if ok {
-						mongoInstrumenter.End(newContext, mongoRequest{
-							CommandName: failedEvent.CommandName,
-							Host:        host,
-						}, nil, errors.New(failedEvent.Failure))
-					}
-				}
-			},
+		if len(opt.Hosts) > 0 {
+			hosts = opt.Hosts
+		}
+		if opt.Monitor != nil {
+			configuredMonitor = opt.Monitor
 		}
 	}
+	monitor := newCommandMonitor(hosts, configuredMonitor)
+	call.SetParam(0, append(opts, options.Client().SetMonitor(monitor)))
+}
+
+func newCommandMonitor(hosts []string, configuredMonitor *event.CommandMonitor) *event.CommandMonitor {
+	spans := sync.Map{}
+	end := func(requestID int64, err error, statusCode string) {
+		span, ok := spans.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		cs := span.(commandSpan)
+		cs.request.StatusCode = statusCode
+		mongoInstrumenter.End(cs.ctx, cs.request, nil, err)
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, startedEvent *event.CommandStartedEvent) {
+			if configuredMonitor != nil && configuredMonitor.Started != nil {
+				configuredMonitor.Started(ctx, startedEvent)
+			}
+			request := db.MongoRequest{
+				CommandName:  startedEvent.CommandName,
+				Host:         commandHost(hosts, startedEvent.ConnectionID),
+				DatabaseName: startedEvent.DatabaseName,
+				Collection:   commandCollection(startedEvent.CommandName, startedEvent.Command),
+				BatchSize:    commandBatchSize(startedEvent.CommandName, startedEvent.Command),
+			}
+			newCtx := mongoInstrumenter.Start(ctx, request)
+			spans.Store(startedEvent.RequestID, commandSpan{ctx: newCtx, request: request})
+		},
+		Succeeded: func(ctx context.Context, succeededEvent *event.CommandSucceededEvent) {
+			if configuredMonitor != nil && configuredMonitor.Succeeded != nil {
+				configuredMonitor.Succeeded(ctx, succeededEvent)
+			}
+			end(succeededEvent.RequestID, nil, "")
+		},
+		Failed: func(ctx context.Context, failedEvent *event.CommandFailedEvent) {
+			if configuredMonitor != nil && configuredMonitor.Failed != nil {
+				configuredMonitor.Failed(ctx, failedEvent)
+			}
+			// The failure is only reported as a message, without the server
+			// error code
+			end(failedEvent.RequestID, errors.New(failedEvent.Failure), "")
+		},
+	}
+}
+
+// commandHost finds the address the command is sent to from the connection
+// id, which is formatted as host:port[-n]
+func commandHost(hosts []string, connectionID string) string {
+	if i := strings.Index(connectionID, "["); i > 0 && strings.HasSuffix(connectionID, "]") {
+		return connectionID[:i]
+	}
+	if len(hosts) > 0 {
+		return hosts[0]
+	}
+	return ""
+}
+
+// commandCollection finds the collection of the command, which is the value
+// of the command name field for collection-level commands, e.g.
+// {"find": "users"}, and is absent for database-level ones
+func commandCollection(commandName string, command bson.Raw) string {
+	if commandName == "getMore" {
+		collection, _ := command.Lookup("collection").StringValueOK()
+		return collection
+	}
+	value, err := command.LookupErr(commandName)
+	if err != nil {
+		return ""
+	}
+	collection, _ := value.StringValueOK()
+	return collection
+}
+
+// commandBatchSize counts the operations of batched write commands, commands
+// with a single operation are not considered as batches
+func commandBatchSize(commandName string, command bson.Raw) int {
+	field, ok := batchFields[commandName]
+	if !ok {
+		return 0
+	}
+	value, err := command.LookupErr(field)
+	if err != nil {
+		return 0
+	}
+	array, ok := value.ArrayOK()
+	if !ok {
+		return 0
+	}
+	values, err := array.Values()
+	if err != nil || len(values) < 2 {
+		return 0
+	}
+	return len(values)
 }
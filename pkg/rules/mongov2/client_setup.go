@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongov2
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver"
+)
+
+var mongoInstrumenter = db.BuildMongoInstrumenter(utils.MONGO_V2_SCOPE_NAME)
+
+var mongoEnabler = config.NewInstrumentationEnabler("mongo")
+
+// batchFields are the fields holding the operations of batched write commands
+var batchFields = map[string]string{
+	"insert": "documents",
+	"update": "updates",
+	"delete": "deletes",
+}
+
+type commandSpan struct {
+	ctx     context.Context
+	request db.MongoRequest
+}
+
+//go:linkname mongoConnectOnEnter go.mongodb.org/mongo-driver/v2/mongo.mongoConnectOnEnter
+func mongoConnectOnEnter(call api.CallContext, opts ...*options.ClientOptions) {
+	if !mongoEnabler.Enable() {
+		return
+	}
+	// Options are merged in order and later fields overwrite earlier ones,
+	// so the monitor appended last wraps whatever monitor is configured
+	var hosts []string
+	var configuredMonitor *event.CommandMonitor
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if len(opt.Hosts) > 0 {
+			hosts = opt.Hosts
+		}
+		if opt.Monitor != nil {
+			configuredMonitor = opt.Monitor
+		}
+	}
+	monitor := newCommandMonitor(hosts, configuredMonitor)
+	call.SetParam(0, append(opts, options.Client().SetMonitor(monitor)))
+}
+
+func newCommandMonitor(hosts []string, configuredMonitor *event.CommandMonitor) *event.CommandMonitor {
+	spans := sync.Map{}
+	end := func(requestID int64, err error, statusCode string) {
+		span, ok := spans.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		cs := span.(commandSpan)
+		cs.request.StatusCode = statusCode
+		mongoInstrumenter.End(cs.ctx, cs.request, nil, err)
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, startedEvent *event.CommandStartedEvent) {
+			if configuredMonitor != nil && configuredMonitor.Started != nil {
+				configuredMonitor.Started(ctx, startedEvent)
+			}
+			request := db.MongoRequest{
+				CommandName:  startedEvent.CommandName,
+				Host:         commandHost(hosts, startedEvent.ConnectionID),
+				DatabaseName: startedEvent.DatabaseName,
+				Collection:   commandCollection(startedEvent.CommandName, startedEvent.Command),
+				BatchSize:    commandBatchSize(startedEvent.CommandName, startedEvent.Command),
+			}
+			newCtx := mongoInstrumenter.Start(ctx, request)
+			spans.Store(startedEvent.RequestID, commandSpan{ctx: newCtx, request: request})
+		},
+		Succeeded: func(ctx context.Context, succeededEvent *event.CommandSucceededEvent) {
+			if configuredMonitor != nil && configuredMonitor.Succeeded != nil {
+				configuredMonitor.Succeeded(ctx, succeededEvent)
+			}
+			end(succeededEvent.RequestID, nil, "")
+		},
+		Failed: func(ctx context.Context, failedEvent *event.CommandFailedEvent) {
+			if configuredMonitor != nil && configuredMonitor.Failed != nil {
+				configuredMonitor.Failed(ctx, failedEvent)
+			}
+			statusCode := ""
+			var serverErr driver.Error
+			if errors.As(failedEvent.Failure, &serverErr) && serverErr.Code != 0 {
+				statusCode = strconv.Itoa(int(serverErr.Code))
+			}
+			end(failedEvent.RequestID, failedEvent.Failure, statusCode)
+		},
+	}
+}
+
+// commandHost finds the address the command is sent to from the connection
+// id, which is formatted as host:port[-n]
+func commandHost(hosts []string, connectionID string) string {
+	if i := strings.Index(connectionID, "["); i > 0 && strings.HasSuffix(connectionID, "]") {
+		return connectionID[:i]
+	}
+	if len(hosts) > 0 {
+		return hosts[0]
+	}
+	return ""
+}
+
+// commandCollection finds the collection of the command, which is the value
+// of the command name field for collection-level commands, e.g.
+// {"find": "users"}, and is absent for database-level ones
+func commandCollection(commandName string, command bson.Raw) string {
+	if commandName == "getMore" {
+		collection, _ := command.Lookup("collection").StringValueOK()
+		return collection
+	}
+	value, err := command.LookupErr(commandName)
+	if err != nil {
+		return ""
+	}
+	collection, _ := value.StringValueOK()
+	return collection
+}
+
+// commandBatchSize counts the operations of batched write commands, commands
+// with a single operation are not considered as batches
+func commandBatchSize(commandName string, command bson.Raw) int {
+	field, ok := batchFields[commandName]
+	if !ok {
+		return 0
+	}
+	value, err := command.LookupErr(field)
+	if err != nil {
+		return 0
+	}
+	array, ok := value.ArrayOK()
+	if !ok {
+		return 0
+	}
+	values, err := array.Values()
+	if err != nil || len(values) < 2 {
+		return 0
+	}
+	return len(values)
+}
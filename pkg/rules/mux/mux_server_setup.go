@@ -16,24 +16,16 @@ package mux
 
 import (
 	"net/http"
-	"os"
 	_ "unsafe"
 
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	mux "github.com/gorilla/mux"
 )
 
-type muxInnerEnabler struct {
-	enabled bool
-}
-
-func (m muxInnerEnabler) Enable() bool {
-	return m.enabled
-}
-
-var muxEnabler = muxInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_MUX_ENABLED") != "false"}
+var muxEnabler = config.NewInstrumentationEnabler("mux")
 
 //go:linkname muxRoute130OnEnter github.com/gorilla/mux.muxRoute130OnEnter
 func muxRoute130OnEnter(call api.CallContext, req *http.Request, route interface{}) {
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// jsAckPrefix is the prefix of the reply subject of messages delivered from
+// a stream
+const jsAckPrefix = "$JS.ACK."
+
+// handlingMsgs holds the messages being handed to a subscription handler,
+// the JetStream messages converted there are already covered by the process
+// span of the handler
+var handlingMsgs sync.Map
+
+func isJetStreamMsg(msg *nats.Msg) bool {
+	return strings.HasPrefix(msg.Reply, jsAckPrefix)
+}
+
+// isStatusMsg reports the heartbeats and flow control messages the server
+// sends to JetStream consumers
+func isStatusMsg(msg *nats.Msg) bool {
+	return len(msg.Data) == 0 && len(msg.Header["Status"]) > 0
+}
+
+// processMsgHandler wraps the handler of a subscription with the process span
+// of every message. Subscriptions on inboxes are set up by the client itself,
+// for pull consumers and async JetStream acks, so only the stream messages
+// delivered to them are processed by the user.
+func processMsgHandler(cb nats.MsgHandler, subj, inboxPrefix string) nats.MsgHandler {
+	onInbox := strings.HasPrefix(subj, nats.InboxPrefix) || strings.HasPrefix(subj, inboxPrefix)
+	return func(msg *nats.Msg) {
+		if msg == nil {
+			cb(msg)
+			return
+		}
+		handlingMsgs.Store(msg, struct{}{})
+		defer handlingMsgs.Delete(msg)
+		if (onInbox && !isJetStreamMsg(msg)) || isStatusMsg(msg) {
+			cb(msg)
+			return
+		}
+		req := natsMessageReq{
+			subject:     msg.Subject,
+			header:      msg.Header,
+			inboxPrefix: inboxPrefix,
+			bodySize:    len(msg.Data),
+		}
+		ctx := processInstrumenter.Start(context.Background(), req)
+		defer processInstrumenter.End(ctx, req, nil, nil)
+		cb(msg)
+	}
+}
+
+// Subscribe, QueueSubscribe and the JetStream push subscriptions all go
+// through Conn.subscribe, which gained the errCh parameter in v1.38.0
+//
+//go:linkname connSubscribeOnEnter github.com/nats-io/nats%2ego.connSubscribeOnEnter
+func connSubscribeOnEnter(call api.CallContext, nc *nats.Conn, subj, queue string, cb nats.MsgHandler, ch chan *nats.Msg, isSync bool, _ interface{}) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	if cb == nil {
+		return
+	}
+	call.SetParam(3, processMsgHandler(cb, subj, inboxPrefixOf(nc)))
+}
+
+// The channel subscriptions of pull consumers remember when they were set up
+// and when they delivered their last message, which starts the receive span
+// of the next one
+//
+//go:linkname connSubscribeOnExit github.com/nats-io/nats%2ego.connSubscribeOnExit
+func connSubscribeOnExit(call api.CallContext, sub *nats.Subscription, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	if sub != nil {
+		sub.OtelLastReceive = time.Now()
+	}
+}
+
+//go:linkname connSubscribeErrChOnEnter github.com/nats-io/nats%2ego.connSubscribeErrChOnEnter
+func connSubscribeErrChOnEnter(call api.CallContext, nc *nats.Conn, subj, queue string, cb nats.MsgHandler, ch chan *nats.Msg, errCh chan error, isSync bool, _ interface{}) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	if cb == nil {
+		return
+	}
+	call.SetParam(3, processMsgHandler(cb, subj, inboxPrefixOf(nc)))
+}
+
+// Every message returned by the Fetch, Next and Messages of a pull consumer
+// is converted by jetStream.toJSMsg. It gets a receive span linked to the
+// producer, unless it is handed to a handler. The span covers the wait since
+// the pull subscription was set up or delivered its previous message. Fetch
+// converts the messages on a goroutine started by the fetcher, which inherits
+// its spans, so the receive span belongs to the trace of the fetcher.
+//
+//go:linkname jetStreamToJSMsgOnEnter github.com/nats-io/nats.go/jetstream.jetStreamToJSMsgOnEnter
+func jetStreamToJSMsgOnEnter(call api.CallContext, _ interface{}, msg *nats.Msg) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	if msg == nil || !isJetStreamMsg(msg) {
+		return
+	}
+	if _, ok := handlingMsgs.Load(msg); ok {
+		return
+	}
+	var startOptions []trace.SpanStartOption
+	producerContext := otel.GetTextMapPropagator().Extract(context.Background(), natsHeaderCarrier{header: msg.Header})
+	if spanContext := trace.SpanContextFromContext(producerContext); spanContext.IsValid() {
+		startOptions = append(startOptions, trace.WithLinks(trace.Link{SpanContext: spanContext}))
+	}
+	endTimestamp := time.Now()
+	startTimestamp := endTimestamp
+	if msg.Sub != nil {
+		if last, ok := msg.Sub.OtelLastReceive.(time.Time); ok {
+			startTimestamp = last
+		}
+		msg.Sub.OtelLastReceive = endTimestamp
+	}
+	receiveInstrumenter.StartAndEndWithOptions(
+		context.Background(),
+		natsMessageReq{
+			subject:  msg.Subject,
+			header:   msg.Header,
+			bodySize: len(msg.Data),
+		},
+		nil,
+		nil,
+		startTimestamp,
+		endTimestamp,
+		startOptions,
+		nil,
+	)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"strings"
+)
+
+var natsEnabler = config.NewInstrumentationEnabler("nats")
+
+var (
+	publishInstrumenter = buildNatsPublishInstrumenter()
+	receiveInstrumenter = buildNatsReceiveInstrumenter()
+	processInstrumenter = buildNatsProcessInstrumenter()
+)
+
+// natsHeaderCarrier reads and writes the trace context from and to the
+// headers of a message. Header keys of NATS are case-sensitive, so the keys
+// are used as they are instead of going through nats.Header.Get and Set.
+type natsHeaderCarrier struct {
+	header nats.Header
+}
+
+func (carrier natsHeaderCarrier) Get(key string) string {
+	if values := carrier.header[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (carrier natsHeaderCarrier) Set(key, value string) {
+	if carrier.header == nil {
+		return
+	}
+	carrier.header[key] = []string{value}
+}
+
+func (carrier natsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.header))
+	for key := range carrier.header {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type natsMessageAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[natsMessageReq, any] = natsMessageAttrsGetter{}
+
+func (getter natsMessageAttrsGetter) GetSystem(request natsMessageReq) string {
+	return "nats"
+}
+
+func (getter natsMessageAttrsGetter) GetDestination(request natsMessageReq) string {
+	return request.subject
+}
+
+func (getter natsMessageAttrsGetter) GetDestinationTemplate(request natsMessageReq) string {
+	return ""
+}
+
+// IsTemporaryDestination reports reply subjects, which are inboxes created
+// for a single request and would blow up the cardinality of span names
+func (getter natsMessageAttrsGetter) IsTemporaryDestination(request natsMessageReq) bool {
+	if strings.HasPrefix(request.subject, nats.InboxPrefix) {
+		return true
+	}
+	return request.inboxPrefix != "" && strings.HasPrefix(request.subject, request.inboxPrefix)
+}
+
+func (getter natsMessageAttrsGetter) IsAnonymousDestination(request natsMessageReq) bool {
+	return false
+}
+
+func (getter natsMessageAttrsGetter) GetConversationId(request natsMessageReq) string {
+	return ""
+}
+
+func (getter natsMessageAttrsGetter) GetMessageBodySize(request natsMessageReq) int64 {
+	return int64(request.bodySize)
+}
+
+func (getter natsMessageAttrsGetter) GetMessageEnvelopSize(request natsMessageReq) int64 {
+	return 0
+}
+
+func (getter natsMessageAttrsGetter) GetMessageId(request natsMessageReq, response any) string {
+	// Only set for JetStream messages published with a message id
+	return natsHeaderCarrier{header: request.header}.Get(nats.MsgIdHdr)
+}
+
+func (getter natsMessageAttrsGetter) GetClientId(request natsMessageReq) string {
+	return ""
+}
+
+func (getter natsMessageAttrsGetter) GetBatchMessageCount(request natsMessageReq, response any) int64 {
+	return 1
+}
+
+func (getter natsMessageAttrsGetter) GetMessageHeader(request natsMessageReq, name string) []string {
+	return request.header[name]
+}
+
+func (getter natsMessageAttrsGetter) GetDestinationPartitionId(request natsMessageReq) string {
+	return ""
+}
+
+func buildNatsPublishInstrumenter() instrumenter.Instrumenter[natsMessageReq, any] {
+	builder := instrumenter.Builder[natsMessageReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_PUBLISH_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsMessageReq, any]{
+			Getter:        natsMessageAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[natsMessageReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsMessageReq, any, natsMessageAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddOperationListeners(message.MessageMetrics("nats.publish")).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request natsMessageReq) propagation.TextMapCarrier {
+				return natsHeaderCarrier{header: request.header}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+// Build the receive instrumenter, the receive span belongs to the trace of
+// the fetcher and links to the producer instead of continuing its trace
+func buildNatsReceiveInstrumenter() instrumenter.Instrumenter[natsMessageReq, any] {
+	builder := instrumenter.Builder[natsMessageReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUME_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsMessageReq, any]{
+			Getter:        natsMessageAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[natsMessageReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsMessageReq, any, natsMessageAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddOperationListeners(message.MessageMetrics("nats.receive")).
+		BuildInstrumenter()
+}
+
+func buildNatsProcessInstrumenter() instrumenter.Instrumenter[natsMessageReq, any] {
+	builder := instrumenter.Builder[natsMessageReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.NATS_CONSUME_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[natsMessageReq, any]{
+			Getter:        natsMessageAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[natsMessageReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[natsMessageReq, any, natsMessageAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddOperationListeners(message.MessageMetrics("nats.process")).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request natsMessageReq) propagation.TextMapCarrier {
+				return natsHeaderCarrier{header: request.header}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
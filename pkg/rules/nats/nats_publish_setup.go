@@ -0,0 +1,329 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// jsApiPrefix is the prefix of the JetStream API, ack and flow control
+// subjects, the client publishes to them on its own behalf
+const jsApiPrefix = "$JS."
+
+// outerSpans holds the span ids of the requests and JetStream publishes in
+// flight. Their message is published through Conn.publish with the context
+// of the outer span already in its headers, which must not start another
+// publish span.
+var outerSpans sync.Map
+
+func inboxPrefixOf(nc *nats.Conn) string {
+	if nc != nil && nc.Opts.InboxPrefix != "" {
+		return nc.Opts.InboxPrefix + "."
+	}
+	return nats.InboxPrefix
+}
+
+// decodeHeader decodes the headers a message is published with, as encoded
+// by nats.Msg
+func decodeHeader(hdr []byte) (nats.Header, bool) {
+	if len(hdr) == 0 {
+		return nats.Header{}, true
+	}
+	header, err := nats.DecodeHeadersMsg(hdr)
+	if err != nil {
+		return nil, false
+	}
+	return header, true
+}
+
+func encodeHeader(header nats.Header) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("NATS/1.0\r\n")
+	if err := http.Header(header).Write(&buf); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\r\n")
+	return buf.Bytes(), nil
+}
+
+// appendHeader appends the keys the trace context was injected with to the
+// encoded headers, so the headers of the user are sent byte for byte. The
+// whole header is encoded again only when the injection replaced one of them.
+func appendHeader(hdr []byte, header, existing nats.Header) ([]byte, error) {
+	added := make([]string, 0, len(header)-len(existing))
+	for key, values := range header {
+		if old, ok := existing[key]; !ok {
+			added = append(added, key)
+		} else if !slices.Equal(old, values) {
+			return encodeHeader(header)
+		}
+	}
+	if len(added) == 0 {
+		return hdr, nil
+	}
+	sort.Strings(added)
+	var buf bytes.Buffer
+	if len(hdr) == 0 {
+		buf.WriteString("NATS/1.0\r\n")
+	} else {
+		// Drop the empty line ending the headers
+		buf.Write(bytes.TrimSuffix(hdr, []byte("\r\n")))
+	}
+	for _, key := range added {
+		for _, value := range header[key] {
+			buf.WriteString(key)
+			buf.WriteString(": ")
+			buf.WriteString(value)
+			buf.WriteString("\r\n")
+		}
+	}
+	buf.WriteString("\r\n")
+	return buf.Bytes(), nil
+}
+
+func isOuterSpan(header nats.Header) bool {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), natsHeaderCarrier{header: header})
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return false
+	}
+	_, ok := outerSpans.Load(spanContext.SpanID())
+	return ok
+}
+
+// startPublishSpan starts the publish span of the message published with the
+// encoded headers at hdrIndex and replaces them with headers carrying the
+// context of the span
+func startPublishSpan(call api.CallContext, ctx context.Context, nc *nats.Conn, subj string, hdr, data []byte, hdrIndex int, outer bool) {
+	if strings.HasPrefix(subj, jsApiPrefix) {
+		return
+	}
+	header, ok := decodeHeader(hdr)
+	if !ok || isOuterSpan(header) {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req := natsMessageReq{
+		subject:     subj,
+		header:      header,
+		inboxPrefix: inboxPrefixOf(nc),
+		bodySize:    len(data),
+	}
+	existing := nats.Header(http.Header(header).Clone())
+	ctx = publishInstrumenter.Start(ctx, req)
+	// Servers without header support reject messages with headers
+	if trace.SpanContextFromContext(ctx).IsValid() && (len(hdr) > 0 || (nc != nil && nc.HeadersSupported())) {
+		if encoded, err := appendHeader(hdr, header, existing); err == nil {
+			call.SetParam(hdrIndex, encoded)
+		}
+	}
+	instrumentationData := map[string]interface{}{
+		"ctx":     ctx,
+		"request": req,
+	}
+	if outer {
+		spanId := trace.SpanContextFromContext(ctx).SpanID()
+		outerSpans.Store(spanId, struct{}{})
+		instrumentationData["outerSpanId"] = spanId
+	}
+	call.SetData(instrumentationData)
+}
+
+func endPublishSpan(call api.CallContext, err error) {
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	if spanId, ok := instrumentationData["outerSpanId"]; ok {
+		outerSpans.Delete(spanId)
+	}
+	ctx := instrumentationData["ctx"].(context.Context)
+	req := instrumentationData["request"].(natsMessageReq)
+	publishInstrumenter.End(ctx, req, nil, err)
+}
+
+// startJetStreamPublishSpan starts the publish span of a message published
+// to a stream, which covers the request waiting for the ack of the server
+func startJetStreamPublishSpan(call api.CallContext, ctx context.Context, m *nats.Msg) {
+	if m == nil {
+		return
+	}
+	if m.Header == nil {
+		m.Header = nats.Header{}
+	}
+	if isOuterSpan(m.Header) {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req := natsMessageReq{
+		subject:  m.Subject,
+		header:   m.Header,
+		bodySize: len(m.Data),
+	}
+	ctx = publishInstrumenter.Start(ctx, req)
+	spanId := trace.SpanContextFromContext(ctx).SpanID()
+	outerSpans.Store(spanId, struct{}{})
+	call.SetData(map[string]interface{}{
+		"ctx":         ctx,
+		"request":     req,
+		"outerSpanId": spanId,
+	})
+}
+
+// Publish, PublishMsg, PublishRequest and the requests all go through
+// Conn.publish, which gained the validateReply parameter in v1.48.0
+//
+//go:linkname connPublishOnEnter github.com/nats-io/nats%2ego.connPublishOnEnter
+func connPublishOnEnter(call api.CallContext, nc *nats.Conn, subj, reply string, hdr, data []byte) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startPublishSpan(call, nil, nc, subj, hdr, data, 3, false)
+}
+
+//go:linkname connPublishValidateReplyOnEnter github.com/nats-io/nats%2ego.connPublishValidateReplyOnEnter
+func connPublishValidateReplyOnEnter(call api.CallContext, nc *nats.Conn, subj, reply string, validateReply bool, hdr, data []byte) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startPublishSpan(call, nil, nc, subj, hdr, data, 4, false)
+}
+
+//go:linkname connPublishOnExit github.com/nats-io/nats%2ego.connPublishOnExit
+func connPublishOnExit(call api.CallContext, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+// The publish span of a request covers the wait for the reply
+//
+//go:linkname connRequestOnEnter github.com/nats-io/nats%2ego.connRequestOnEnter
+func connRequestOnEnter(call api.CallContext, nc *nats.Conn, subj string, hdr, data []byte, timeout time.Duration) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startPublishSpan(call, nil, nc, subj, hdr, data, 2, true)
+}
+
+//go:linkname connRequestOnExit github.com/nats-io/nats%2ego.connRequestOnExit
+func connRequestOnExit(call api.CallContext, msg *nats.Msg, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+//go:linkname connRequestWithContextOnEnter github.com/nats-io/nats%2ego.connRequestWithContextOnEnter
+func connRequestWithContextOnEnter(call api.CallContext, nc *nats.Conn, ctx context.Context, subj string, hdr, data []byte) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startPublishSpan(call, ctx, nc, subj, hdr, data, 3, true)
+}
+
+//go:linkname connRequestWithContextOnExit github.com/nats-io/nats%2ego.connRequestWithContextOnExit
+func connRequestWithContextOnExit(call api.CallContext, msg *nats.Msg, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+//go:linkname jsPublishMsgOnEnter github.com/nats-io/nats%2ego.jsPublishMsgOnEnter
+func jsPublishMsgOnEnter(call api.CallContext, _ interface{}, m *nats.Msg, opts ...nats.PubOpt) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startJetStreamPublishSpan(call, nil, m)
+}
+
+//go:linkname jsPublishMsgOnExit github.com/nats-io/nats%2ego.jsPublishMsgOnExit
+func jsPublishMsgOnExit(call api.CallContext, ack *nats.PubAck, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+//go:linkname jetStreamPublishMsgOnEnter github.com/nats-io/nats.go/jetstream.jetStreamPublishMsgOnEnter
+func jetStreamPublishMsgOnEnter(call api.CallContext, _ interface{}, ctx context.Context, m *nats.Msg, opts ...jetstream.PublishOpt) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startJetStreamPublishSpan(call, ctx, m)
+}
+
+//go:linkname jetStreamPublishMsgOnExit github.com/nats-io/nats.go/jetstream.jetStreamPublishMsgOnExit
+func jetStreamPublishMsgOnExit(call api.CallContext, ack *jetstream.PubAck, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+// The publish span of an async JetStream publish covers sending the message,
+// the ack of the stream is received later through the returned future
+//
+//go:linkname jsPublishMsgAsyncOnEnter github.com/nats-io/nats%2ego.jsPublishMsgAsyncOnEnter
+func jsPublishMsgAsyncOnEnter(call api.CallContext, _ interface{}, m *nats.Msg, opts ...nats.PubOpt) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startJetStreamPublishSpan(call, nil, m)
+}
+
+//go:linkname jsPublishMsgAsyncOnExit github.com/nats-io/nats%2ego.jsPublishMsgAsyncOnExit
+func jsPublishMsgAsyncOnExit(call api.CallContext, future nats.PubAckFuture, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
+
+//go:linkname jetStreamPublishMsgAsyncOnEnter github.com/nats-io/nats.go/jetstream.jetStreamPublishMsgAsyncOnEnter
+func jetStreamPublishMsgAsyncOnEnter(call api.CallContext, _ interface{}, m *nats.Msg, opts ...jetstream.PublishOpt) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	startJetStreamPublishSpan(call, nil, m)
+}
+
+//go:linkname jetStreamPublishMsgAsyncOnExit github.com/nats-io/nats.go/jetstream.jetStreamPublishMsgAsyncOnExit
+func jetStreamPublishMsgAsyncOnExit(call api.CallContext, future jetstream.PubAckFuture, err error) {
+	if !natsEnabler.Enable() {
+		return
+	}
+	endPublishSpan(call, err)
+}
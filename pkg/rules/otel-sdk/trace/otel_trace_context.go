@@ -17,13 +17,52 @@
 package trace_context
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 
 	trace "go.opentelemetry.io/otel/trace"
 )
 
 const maxSpans = 300
 
+// processParent is the span context the parent process propagated through the
+// environment, root spans of this process continue its trace
+var processParent trace.SpanContext
+
+// processServing is set by the first server or consumer span, from then on the
+// process works for its own callers rather than for its parent process
+var processServing atomic.Bool
+
+// SetProcessParent sets the span context that root spans other than server
+// and consumer spans are children of. Server and consumer spans are where
+// their own traces start, when their callers propagate no context. Once the
+// first of them is started, later root spans start their own traces too.
+func SetProcessParent(sc trace.SpanContext) {
+	processParent = sc
+}
+
+// processParentOf returns the span context of the parent process if a root
+// span of the kind continues its trace, or an invalid span context otherwise
+func processParentOf(kind trace.SpanKind) trace.SpanContext {
+	if isServingKind(kind) || processServing.Load() {
+		return trace.SpanContext{}
+	}
+	return processParent
+}
+
+// noteServing records that the process serves its own callers when a server
+// or consumer span is started, whether or not it has a parent
+func noteServing(kind trace.SpanKind) {
+	if isServingKind(kind) && !processServing.Load() {
+		processServing.Store(true)
+	}
+}
+
+func isServingKind(kind trace.SpanKind) bool {
+	return kind == trace.SpanKindServer || kind == trace.SpanKindConsumer
+}
+
 type traceContext struct {
 	sw  *spanWrapper
 	n   int
@@ -176,3 +215,18 @@ func LocalRootSpanFromGLS() trace.Span {
 	}
 	return gls.(*traceContext).lcs
 }
+
+// SpanContextToGLS makes the span context propagated by a caller the current
+// span context of the goroutine when no span is started for the call, spans
+// started on the goroutine afterwards are its children. The returned function
+// removes it again.
+func SpanContextToGLS(sc trace.SpanContext) func() {
+	if !sc.IsValid() {
+		return func() {}
+	}
+	span := trace.SpanFromContext(trace.ContextWithRemoteSpanContext(context.Background(), sc))
+	traceContextAddSpan(span)
+	return func() {
+		traceContextDelSpan(span)
+	}
+}
@@ -78,6 +78,7 @@ type runtimeTracer interface {
 
 // newSpan returns a new configured span.
 func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanConfig) trace.Span {
+	noteServing(config.SpanKind())
 	// If told explicitly to make this a new root use a zero value SpanContext
 	// as a parent which contains an invalid trace ID and is not remote.
 	var psc trace.SpanContext
@@ -87,6 +88,9 @@ func (tr *tracer) newSpan(ctx context.Context, name string, config *trace.SpanCo
 		if p := trace.SpanFromContext(ctx); p == nil || !p.SpanContext().IsValid() {
 			if tc := getOrInitTraceContext(); tc.size() != 0 {
 				psc = tc.tail().SpanContext()
+			} else if pp := processParentOf(config.SpanKind()); pp.IsValid() {
+				psc = pp
+				ctx = trace.ContextWithRemoteSpanContext(ctx, psc)
 			}
 		} else {
 			psc = trace.SpanContextFromContext(ctx)
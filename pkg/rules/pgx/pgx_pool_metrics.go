@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgx
+
+import (
+	"sync"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	poolMu    sync.Mutex
+	poolUnreg = map[*pgxpool.Pool]func(){}
+)
+
+// registerPoolMetrics reports pgxpool.Stat as db.client.connection.* metrics.
+// pgxpool does not count waits separately, so acquires from an empty pool
+// are reported as waits and the total duration of acquires as wait duration.
+// Idle connections are only closed for exceeding their idle time.
+func registerPoolMetrics(pool *pgxpool.Pool, endpoint string) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if _, ok := poolUnreg[pool]; ok {
+		return
+	}
+	poolUnreg[pool] = db.RegisterDbClientPool("pgx", endpoint, func() db.DbClientPoolStats {
+		s := pool.Stat()
+		return db.DbClientPoolStats{
+			MaxOpenConnections: int64(s.MaxConns()),
+			InUse:              int64(s.AcquiredConns()),
+			Idle:               int64(s.IdleConns()),
+			WaitCount:          s.EmptyAcquireCount(),
+			WaitDuration:       s.AcquireDuration(),
+			MaxIdleTimeClosed:  s.MaxIdleDestroyCount(),
+			MaxLifetimeClosed:  s.MaxLifetimeDestroyCount(),
+		}
+	})
+}
+
+func unregisterPoolMetrics(pool *pgxpool.Pool) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if unregister, ok := poolUnreg[pool]; ok {
+		unregister()
+		delete(poolUnreg, pool)
+	}
+}
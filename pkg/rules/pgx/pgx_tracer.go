@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgx
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type pgxSpanKey struct{}
+
+// pgxSpan is the span a tracer starts and later ends with the context
+// returned by the start hook. Queries of a batch are read one by one, so each
+// of them is reported as a child span lasting since the previous one ended.
+type pgxSpan struct {
+	request pgxRequest
+	lastEnd time.Time
+}
+
+// pgxTracer implements every tracer interface of pgx. The tracer configured
+// by the application, if any, is kept and called inside our spans.
+type pgxTracer struct {
+	configured pgx.QueryTracer
+	addr       string
+	dbName     string
+}
+
+func newPgxTracer(config *pgx.ConnConfig) *pgxTracer {
+	return &pgxTracer{
+		configured: config.Tracer,
+		addr:       addrOf(config),
+		dbName:     config.Database,
+	}
+}
+
+func (t *pgxTracer) newRequest(operation, statement string, params []any) pgxRequest {
+	return pgxRequest{
+		operation: operation,
+		statement: statement,
+		params:    params,
+		addr:      t.addr,
+		dbName:    t.dbName,
+	}
+}
+
+func (t *pgxTracer) start(ctx context.Context, request pgxRequest) context.Context {
+	ctx = pgxInstrumenter.Start(ctx, request)
+	return context.WithValue(ctx, pgxSpanKey{}, &pgxSpan{request: request, lastEnd: time.Now()})
+}
+
+func (t *pgxTracer) end(ctx context.Context, response any, err error) {
+	span, ok := ctx.Value(pgxSpanKey{}).(*pgxSpan)
+	if !ok {
+		return
+	}
+	pgxInstrumenter.End(ctx, span.request, response, err)
+}
+
+func (t *pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx = t.start(ctx, t.newRequest(operationOf(data.SQL), data.SQL, data.Args))
+	if t.configured != nil {
+		ctx = t.configured.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t *pgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if t.configured != nil {
+		t.configured.TraceQueryEnd(ctx, conn, data)
+	}
+	t.end(ctx, responseOf(data.CommandTag), data.Err)
+}
+
+func (t *pgxTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	request := t.newRequest("BATCH", "", nil)
+	if data.Batch != nil {
+		request.batchSize = data.Batch.Len()
+	}
+	ctx = t.start(ctx, request)
+	if bt, ok := t.configured.(pgx.BatchTracer); ok {
+		ctx = bt.TraceBatchStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t *pgxTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if bt, ok := t.configured.(pgx.BatchTracer); ok {
+		bt.TraceBatchQuery(ctx, conn, data)
+	}
+	span, ok := ctx.Value(pgxSpanKey{}).(*pgxSpan)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	pgxInstrumenter.StartAndEnd(ctx, t.newRequest(operationOf(data.SQL), data.SQL, data.Args),
+		responseOf(data.CommandTag), data.Err, span.lastEnd, now)
+	span.lastEnd = now
+}
+
+func (t *pgxTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	if bt, ok := t.configured.(pgx.BatchTracer); ok {
+		bt.TraceBatchEnd(ctx, conn, data)
+	}
+	t.end(ctx, nil, data.Err)
+}
+
+func (t *pgxTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	table := strings.Join(data.TableName, ".")
+	statement := "COPY " + table
+	if len(data.ColumnNames) > 0 {
+		statement += " (" + strings.Join(data.ColumnNames, ", ") + ")"
+	}
+	request := t.newRequest("COPY", statement+" FROM STDIN", nil)
+	request.collection = table
+	ctx = t.start(ctx, request)
+	if ct, ok := t.configured.(pgx.CopyFromTracer); ok {
+		ctx = ct.TraceCopyFromStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t *pgxTracer) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	if ct, ok := t.configured.(pgx.CopyFromTracer); ok {
+		ct.TraceCopyFromEnd(ctx, conn, data)
+	}
+	t.end(ctx, nil, data.Err)
+}
+
+func (t *pgxTracer) TracePrepareStart(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
+	ctx = t.start(ctx, t.newRequest("PREPARE", data.SQL, nil))
+	if pt, ok := t.configured.(pgx.PrepareTracer); ok {
+		ctx = pt.TracePrepareStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t *pgxTracer) TracePrepareEnd(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareEndData) {
+	if pt, ok := t.configured.(pgx.PrepareTracer); ok {
+		pt.TracePrepareEnd(ctx, conn, data)
+	}
+	t.end(ctx, nil, data.Err)
+}
+
+// TraceConnectStart and TraceConnectEnd only forward to the configured
+// tracer, connecting is not a database operation
+func (t *pgxTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	if ct, ok := t.configured.(pgx.ConnectTracer); ok {
+		ctx = ct.TraceConnectStart(ctx, data)
+	}
+	return ctx
+}
+
+func (t *pgxTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	if ct, ok := t.configured.(pgx.ConnectTracer); ok {
+		ct.TraceConnectEnd(ctx, data)
+	}
+}
+
+// addrOf returns host:port of the server, or the directory of the unix socket
+func addrOf(config *pgx.ConnConfig) string {
+	if strings.HasPrefix(config.Host, "/") || config.Port == 0 {
+		return config.Host
+	}
+	return db.DSNInfo{Host: config.Host, Port: strconv.Itoa(int(config.Port))}.Addr()
+}
+
+// operationOf returns the leading keyword of the statement
+func operationOf(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// responseOf reports the returned rows of SELECT statements only, the rows
+// affected by other statements are not returned rows
+func responseOf(tag pgconn.CommandTag) any {
+	if tag.Select() {
+		return pgxResponse{returnedRows: tag.RowsAffected()}
+	}
+	return nil
+}
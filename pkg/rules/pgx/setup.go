@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgx
+
+import (
+	_ "unsafe"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var pgxEnabler = config.NewInstrumentationEnabler("pgx")
+
+var pgxInstrumenter = BuildPgxOtelInstrumenter()
+
+// installTracer wraps the tracer of the config, the connections created from
+// the config, including those of a pool, inherit it
+func installTracer(config *pgx.ConnConfig) {
+	if config == nil {
+		return
+	}
+	if _, ok := config.Tracer.(*pgxTracer); ok {
+		return
+	}
+	config.Tracer = newPgxTracer(config)
+}
+
+//go:linkname afterPgxParseConfig github.com/jackc/pgx/v5.afterPgxParseConfig
+func afterPgxParseConfig(call api.CallContext, config *pgx.ConnConfig, err error) {
+	if !pgxEnabler.Enable() || err != nil {
+		return
+	}
+	installTracer(config)
+}
+
+//go:linkname afterPgxpoolParseConfig github.com/jackc/pgx/v5/pgxpool.afterPgxpoolParseConfig
+func afterPgxpoolParseConfig(call api.CallContext, config *pgxpool.Config, err error) {
+	if !pgxEnabler.Enable() || err != nil || config == nil {
+		return
+	}
+	installTracer(config.ConnConfig)
+}
+
+//go:linkname afterPgxpoolNewWithConfig github.com/jackc/pgx/v5/pgxpool.afterPgxpoolNewWithConfig
+func afterPgxpoolNewWithConfig(call api.CallContext, pool *pgxpool.Pool, err error) {
+	if !pgxEnabler.Enable() || err != nil || pool == nil {
+		return
+	}
+	registerPoolMetrics(pool, addrOf(pool.Config().ConnConfig))
+}
+
+//go:linkname beforePgxpoolClose github.com/jackc/pgx/v5/pgxpool.beforePgxpoolClose
+func beforePgxpoolClose(call api.CallContext, pool *pgxpool.Pool) {
+	unregisterPoolMetrics(pool)
+}
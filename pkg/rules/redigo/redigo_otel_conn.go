@@ -17,15 +17,16 @@ package redigo
 import (
 	"container/list"
 	"context"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/gomodule/redigo/redis"
-	"os"
-	"strconv"
 	"time"
 )
 
 const max_queue_length = 2048
 
-var configuredQueueLength int
+var maxQueueLength = config.Int("redigo", "max.queue.length", max_queue_length,
+	"Maximum number of pending commands tracked per pipelined redigo connection.",
+	"MAX_REDIGO_QUEUE_LENGTH")
 
 var commandQueue = list.New()
 
@@ -111,13 +112,8 @@ func pop() *redigoRequest {
 }
 
 func getMaxQueueLength() int {
-	if configuredQueueLength == 0 {
-		var e = os.Getenv("MAX_REDIGO_QUEUE_LENGTH")
-		if e != "" {
-			configuredQueueLength, _ = strconv.Atoi(os.Getenv(e))
-		} else {
-			configuredQueueLength = max_queue_length
-		}
+	if l := maxQueueLength.Get(); l > 0 {
+		return l
 	}
-	return configuredQueueLength
+	return max_queue_length
 }
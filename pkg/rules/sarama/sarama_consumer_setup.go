@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"sync"
+	_ "unsafe"
+
+	"github.com/IBM/sarama"
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+)
+
+// processSpans holds the process span of every message handed to a
+// ConsumerGroupHandler. The span starts when the handler receives the message
+// from the claim and ends when the message is marked, when the handler
+// receives the next message or when ConsumeClaim returns. A message marked
+// before its span is stored ends with the next message.
+var processSpans sync.Map
+
+type saramaProcessSpan struct {
+	mu  sync.Mutex
+	ctx context.Context
+	req saramaConsumerReq
+}
+
+func (p *saramaProcessSpan) end() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx != nil {
+		consumerInstrumenter.End(p.ctx, p.req, nil, nil)
+		p.ctx = nil
+	}
+}
+
+func endProcessSpan(msg *sarama.ConsumerMessage) {
+	if msg == nil {
+		return
+	}
+	if span, ok := processSpans.LoadAndDelete(msg); ok {
+		span.(*saramaProcessSpan).end()
+	}
+}
+
+// startProcessSpan starts the process span of a message received by the
+// handler. It runs on the goroutine forwarding the messages of the claim, the
+// span is kept out of the context of that goroutine since it ends on the
+// goroutine of the handler. The headers of the message are left untouched.
+func startProcessSpan(msg *sarama.ConsumerMessage) {
+	span := &saramaProcessSpan{req: saramaConsumerReq{msg: msg}}
+	api.RunDetached(func() {
+		span.ctx = consumerInstrumenter.Start(context.Background(), span.req)
+	})
+	processSpans.Store(msg, span)
+}
+
+// saramaConsumerGroupHandler wraps the handler passed to ConsumerGroup.Consume
+// so that the messages of every claim are traced
+type saramaConsumerGroupHandler struct {
+	sarama.ConsumerGroupHandler
+}
+
+func (h saramaConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	tracedClaim := &saramaConsumerGroupClaim{
+		ConsumerGroupClaim: claim,
+		messages:           make(chan *sarama.ConsumerMessage),
+		done:               make(chan struct{}),
+	}
+	go tracedClaim.forward()
+	defer tracedClaim.close()
+	return h.ConsumerGroupHandler.ConsumeClaim(session, tracedClaim)
+}
+
+// saramaConsumerGroupClaim hands the messages of the claim over one by one,
+// starting the process span of each message once the handler receives it
+type saramaConsumerGroupClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+	done     chan struct{}
+	mu       sync.Mutex
+	last     *sarama.ConsumerMessage
+	closed   bool
+}
+
+func (c *saramaConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+func (c *saramaConsumerGroupClaim) forward() {
+	defer close(c.messages)
+	for msg := range c.ConsumerGroupClaim.Messages() {
+		if msg == nil {
+			continue
+		}
+		select {
+		case c.messages <- msg:
+			// The handler is done with the previous message once it
+			// receives the next one
+			c.mu.Lock()
+			endProcessSpan(c.last)
+			c.last = nil
+			if !c.closed {
+				startProcessSpan(msg)
+				c.last = msg
+			}
+			c.mu.Unlock()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *saramaConsumerGroupClaim) close() {
+	close(c.done)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	endProcessSpan(c.last)
+	c.last = nil
+}
+
+//go:linkname consumerGroupConsumeOnEnter github.com/IBM/sarama.consumerGroupConsumeOnEnter
+func consumerGroupConsumeOnEnter(call api.CallContext, _ interface{}, ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	if handler == nil {
+		return
+	}
+	if _, ok := handler.(saramaConsumerGroupHandler); ok {
+		return
+	}
+	call.SetParam(3, saramaConsumerGroupHandler{ConsumerGroupHandler: handler})
+}
+
+//go:linkname consumerGroupSessionMarkMessageOnEnter github.com/IBM/sarama.consumerGroupSessionMarkMessageOnEnter
+func consumerGroupSessionMarkMessageOnEnter(call api.CallContext, _ interface{}, msg *sarama.ConsumerMessage, metadata string) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	endProcessSpan(msg)
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"strconv"
+)
+
+var saramaEnabler = config.NewInstrumentationEnabler("sarama")
+
+var (
+	producerInstrumenter = buildSaramaProducerInstrumenter()
+	consumerInstrumenter = buildSaramaConsumerInstrumenter()
+)
+
+// saramaProducerCarrier injects the trace context into the record headers of
+// a produced message
+type saramaProducerCarrier struct {
+	msg *sarama.ProducerMessage
+}
+
+func (carrier saramaProducerCarrier) Get(key string) string {
+	for _, header := range carrier.msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (carrier saramaProducerCarrier) Set(key, value string) {
+	for i, header := range carrier.msg.Headers {
+		if string(header.Key) == key {
+			carrier.msg.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	carrier.msg.Headers = append(carrier.msg.Headers, sarama.RecordHeader{
+		Key:   []byte(key),
+		Value: []byte(value),
+	})
+}
+
+func (carrier saramaProducerCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.msg.Headers))
+	for _, header := range carrier.msg.Headers {
+		keys = append(keys, string(header.Key))
+	}
+	return keys
+}
+
+// saramaConsumerCarrier extracts the trace context from the record headers of
+// a consumed message, and replaces it with the context of the process span
+type saramaConsumerCarrier struct {
+	msg *sarama.ConsumerMessage
+}
+
+func (carrier saramaConsumerCarrier) Get(key string) string {
+	for _, header := range carrier.msg.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (carrier saramaConsumerCarrier) Set(key, value string) {
+	for _, header := range carrier.msg.Headers {
+		if header != nil && string(header.Key) == key {
+			header.Value = []byte(value)
+			return
+		}
+	}
+	carrier.msg.Headers = append(carrier.msg.Headers, &sarama.RecordHeader{
+		Key:   []byte(key),
+		Value: []byte(value),
+	})
+}
+
+func (carrier saramaConsumerCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier.msg.Headers))
+	for _, header := range carrier.msg.Headers {
+		if header != nil {
+			keys = append(keys, string(header.Key))
+		}
+	}
+	return keys
+}
+
+type saramaProducerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[saramaProducerReq, any] = saramaProducerAttrsGetter{}
+
+func (getter saramaProducerAttrsGetter) GetSystem(request saramaProducerReq) string {
+	return "kafka"
+}
+
+func (getter saramaProducerAttrsGetter) GetDestination(request saramaProducerReq) string {
+	return request.msg.Topic
+}
+
+func (getter saramaProducerAttrsGetter) GetDestinationTemplate(request saramaProducerReq) string {
+	return ""
+}
+
+func (getter saramaProducerAttrsGetter) IsTemporaryDestination(request saramaProducerReq) bool {
+	return false
+}
+
+func (getter saramaProducerAttrsGetter) IsAnonymousDestination(request saramaProducerReq) bool {
+	return false
+}
+
+func (getter saramaProducerAttrsGetter) GetConversationId(request saramaProducerReq) string {
+	return ""
+}
+
+func (getter saramaProducerAttrsGetter) GetMessageBodySize(request saramaProducerReq) int64 {
+	if request.msg.Value == nil {
+		return 0
+	}
+	return int64(request.msg.Value.Length())
+}
+
+func (getter saramaProducerAttrsGetter) GetMessageEnvelopSize(request saramaProducerReq) int64 {
+	return 0
+}
+
+func (getter saramaProducerAttrsGetter) GetMessageId(request saramaProducerReq, response any) string {
+	return ""
+}
+
+func (getter saramaProducerAttrsGetter) GetClientId(request saramaProducerReq) string {
+	return ""
+}
+
+func (getter saramaProducerAttrsGetter) GetBatchMessageCount(request saramaProducerReq, response any) int64 {
+	return 1
+}
+
+func (getter saramaProducerAttrsGetter) GetMessageHeader(request saramaProducerReq, name string) []string {
+	var headerValues []string
+	for _, header := range request.msg.Headers {
+		if string(header.Key) == name {
+			headerValues = append(headerValues, string(header.Value))
+		}
+	}
+	return headerValues
+}
+
+func (getter saramaProducerAttrsGetter) GetDestinationPartitionId(request saramaProducerReq) string {
+	// The partition is only known once the message is produced
+	return ""
+}
+
+type saramaConsumerAttrsGetter struct{}
+
+var _ message.MessageAttrsGetter[saramaConsumerReq, any] = saramaConsumerAttrsGetter{}
+
+func (getter saramaConsumerAttrsGetter) GetSystem(request saramaConsumerReq) string {
+	return "kafka"
+}
+
+func (getter saramaConsumerAttrsGetter) GetDestination(request saramaConsumerReq) string {
+	return request.msg.Topic
+}
+
+func (getter saramaConsumerAttrsGetter) GetDestinationTemplate(request saramaConsumerReq) string {
+	return ""
+}
+
+func (getter saramaConsumerAttrsGetter) IsTemporaryDestination(request saramaConsumerReq) bool {
+	return false
+}
+
+func (getter saramaConsumerAttrsGetter) IsAnonymousDestination(request saramaConsumerReq) bool {
+	return false
+}
+
+func (getter saramaConsumerAttrsGetter) GetConversationId(request saramaConsumerReq) string {
+	return ""
+}
+
+func (getter saramaConsumerAttrsGetter) GetMessageBodySize(request saramaConsumerReq) int64 {
+	return int64(len(request.msg.Value))
+}
+
+func (getter saramaConsumerAttrsGetter) GetMessageEnvelopSize(request saramaConsumerReq) int64 {
+	return 0
+}
+
+func (getter saramaConsumerAttrsGetter) GetMessageId(request saramaConsumerReq, response any) string {
+	return ""
+}
+
+func (getter saramaConsumerAttrsGetter) GetClientId(request saramaConsumerReq) string {
+	return ""
+}
+
+func (getter saramaConsumerAttrsGetter) GetBatchMessageCount(request saramaConsumerReq, response any) int64 {
+	return 1
+}
+
+func (getter saramaConsumerAttrsGetter) GetMessageHeader(request saramaConsumerReq, name string) []string {
+	var headerValues []string
+	for _, header := range request.msg.Headers {
+		if header != nil && string(header.Key) == name {
+			headerValues = append(headerValues, string(header.Value))
+		}
+	}
+	return headerValues
+}
+
+func (getter saramaConsumerAttrsGetter) GetDestinationPartitionId(request saramaConsumerReq) string {
+	return strconv.Itoa(int(request.msg.Partition))
+}
+
+// saramaProducerAttrsExtractor records where the message was produced to
+type saramaProducerAttrsExtractor struct{}
+
+func (extractor *saramaProducerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request saramaProducerReq) ([]attribute.KeyValue, context.Context) {
+	return attributes, parentContext
+}
+
+func (extractor *saramaProducerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request saramaProducerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	if err == nil {
+		attributes = append(attributes,
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(request.msg.Partition))),
+			semconv.MessagingKafkaOffset(int(request.msg.Offset)),
+		)
+	}
+	return attributes, ctx
+}
+
+// saramaConsumerAttrsExtractor records the offset of the consumed message
+type saramaConsumerAttrsExtractor struct{}
+
+func (extractor *saramaConsumerAttrsExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request saramaConsumerReq) ([]attribute.KeyValue, context.Context) {
+	return append(attributes, semconv.MessagingKafkaOffset(int(request.msg.Offset))), parentContext
+}
+
+func (extractor *saramaConsumerAttrsExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request saramaConsumerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	return attributes, ctx
+}
+
+func buildSaramaProducerInstrumenter() instrumenter.Instrumenter[saramaProducerReq, any] {
+	builder := instrumenter.Builder[saramaProducerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.SARAMA_PRODUCER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[saramaProducerReq, any]{
+			Getter:        saramaProducerAttrsGetter{},
+			OperationName: message.PUBLISH,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[saramaProducerReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[saramaProducerReq, any, saramaProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddAttributesExtractor(&saramaProducerAttrsExtractor{}).
+		AddOperationListeners(message.MessageMetrics("sarama.producer")).
+		BuildPropagatingToDownstreamInstrumenter(
+			func(request saramaProducerReq) propagation.TextMapCarrier {
+				return saramaProducerCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
+
+func buildSaramaConsumerInstrumenter() instrumenter.Instrumenter[saramaConsumerReq, any] {
+	builder := instrumenter.Builder[saramaConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.SARAMA_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[saramaConsumerReq, any]{
+			Getter:        saramaConsumerAttrsGetter{},
+			OperationName: message.PROCESS,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[saramaConsumerReq]{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[saramaConsumerReq, any, saramaConsumerAttrsGetter]{
+			Operation: message.PROCESS,
+		}).
+		AddAttributesExtractor(&saramaConsumerAttrsExtractor{}).
+		AddOperationListeners(message.MessageMetrics("sarama.consumer")).
+		BuildPropagatingFromUpstreamInstrumenter(
+			func(request saramaConsumerReq) propagation.TextMapCarrier {
+				return saramaConsumerCarrier{msg: request.msg}
+			},
+			otel.GetTextMapPropagator(),
+		)
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarama
+
+import (
+	"context"
+	"errors"
+	"sync"
+	_ "unsafe"
+
+	"github.com/IBM/sarama"
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// producerSpans holds the publish span of every message in flight. Messages
+// sent by the SyncProducer are ended when SendMessage(s) returns, messages
+// sent through AsyncProducer.Input() are ended when the producer returns them
+// as a success or an error.
+var producerSpans sync.Map
+
+type saramaProducerSpan struct {
+	ctx  context.Context
+	req  saramaProducerReq
+	sync bool
+}
+
+func startProducerSpan(msg *sarama.ProducerMessage, synchronous bool, options ...trace.SpanStartOption) {
+	req := saramaProducerReq{msg: msg}
+	var ctx context.Context
+	if synchronous {
+		ctx = producerInstrumenter.Start(context.Background(), req, options...)
+	} else {
+		// The span of an asynchronous message ends on another goroutine,
+		// it is kept out of the goroutine local context of the dispatcher
+		api.RunDetached(func() {
+			ctx = producerInstrumenter.Start(context.Background(), req, options...)
+		})
+	}
+	producerSpans.Store(msg, &saramaProducerSpan{ctx: ctx, req: req, sync: synchronous})
+}
+
+func endProducerSpan(msg *sarama.ProducerMessage, synchronous bool, err error) {
+	value, ok := producerSpans.Load(msg)
+	if !ok || value.(*saramaProducerSpan).sync != synchronous {
+		return
+	}
+	if producerSpans.CompareAndDelete(msg, value) {
+		span := value.(*saramaProducerSpan)
+		producerInstrumenter.End(span.ctx, span.req, nil, err)
+	}
+}
+
+// saramaProducerInterceptor starts the publish span of the messages written
+// to AsyncProducer.Input(). It runs in the dispatcher goroutine, so the span
+// starts a new trace instead of inheriting the context of that goroutine.
+type saramaProducerInterceptor struct{}
+
+func (interceptor saramaProducerInterceptor) OnSend(msg *sarama.ProducerMessage) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	// Retried messages and messages of the SyncProducer are already traced
+	if _, ok := producerSpans.Load(msg); ok {
+		return
+	}
+	startProducerSpan(msg, false, trace.WithNewRoot())
+}
+
+// saramaInterceptedClient hands the producer a copy of the client config
+// with the interceptor added, the config of the client is left untouched.
+type saramaInterceptedClient struct {
+	sarama.Client
+	conf *sarama.Config
+}
+
+func (client saramaInterceptedClient) Config() *sarama.Config {
+	return client.conf
+}
+
+//go:linkname newAsyncProducerOnEnter github.com/IBM/sarama.newAsyncProducerOnEnter
+func newAsyncProducerOnEnter(call api.CallContext, client sarama.Client) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	if client == nil || client.Config() == nil {
+		return
+	}
+	if _, ok := client.(saramaInterceptedClient); ok {
+		return
+	}
+	conf := *client.Config()
+	interceptors := make([]sarama.ProducerInterceptor, 0, len(conf.Producer.Interceptors)+1)
+	interceptors = append(interceptors, conf.Producer.Interceptors...)
+	conf.Producer.Interceptors = append(interceptors, saramaProducerInterceptor{})
+	call.SetParam(0, saramaInterceptedClient{Client: client, conf: &conf})
+}
+
+//go:linkname asyncProducerReturnSuccessesOnEnter github.com/IBM/sarama.asyncProducerReturnSuccessesOnEnter
+func asyncProducerReturnSuccessesOnEnter(call api.CallContext, _ interface{}, batch []*sarama.ProducerMessage) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	for _, msg := range batch {
+		endProducerSpan(msg, false, nil)
+	}
+}
+
+//go:linkname asyncProducerReturnErrorOnEnter github.com/IBM/sarama.asyncProducerReturnErrorOnEnter
+func asyncProducerReturnErrorOnEnter(call api.CallContext, _ interface{}, msg *sarama.ProducerMessage, err error) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	endProducerSpan(msg, false, err)
+}
+
+//go:linkname asyncProducerReturnErrorsOnEnter github.com/IBM/sarama.asyncProducerReturnErrorsOnEnter
+func asyncProducerReturnErrorsOnEnter(call api.CallContext, _ interface{}, batch []*sarama.ProducerMessage, err error) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	for _, msg := range batch {
+		endProducerSpan(msg, false, err)
+	}
+}
+
+//go:linkname syncProducerSendMessageOnEnter github.com/IBM/sarama.syncProducerSendMessageOnEnter
+func syncProducerSendMessageOnEnter(call api.CallContext, _ interface{}, msg *sarama.ProducerMessage) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	if msg == nil {
+		return
+	}
+	startProducerSpan(msg, true)
+	call.SetData(msg)
+}
+
+//go:linkname syncProducerSendMessageOnExit github.com/IBM/sarama.syncProducerSendMessageOnExit
+func syncProducerSendMessageOnExit(call api.CallContext, partition int32, offset int64, err error) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	msg, ok := call.GetData().(*sarama.ProducerMessage)
+	if !ok {
+		return
+	}
+	endProducerSpan(msg, true, err)
+}
+
+//go:linkname syncProducerSendMessagesOnEnter github.com/IBM/sarama.syncProducerSendMessagesOnEnter
+func syncProducerSendMessagesOnEnter(call api.CallContext, _ interface{}, msgs []*sarama.ProducerMessage) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	for _, msg := range msgs {
+		if msg != nil {
+			startProducerSpan(msg, true)
+		}
+	}
+	call.SetData(msgs)
+}
+
+//go:linkname syncProducerSendMessagesOnExit github.com/IBM/sarama.syncProducerSendMessagesOnExit
+func syncProducerSendMessagesOnExit(call api.CallContext, err error) {
+	if !saramaEnabler.Enable() {
+		return
+	}
+	msgs, ok := call.GetData().([]*sarama.ProducerMessage)
+	if !ok {
+		return
+	}
+	var producerErrors sarama.ProducerErrors
+	errors.As(err, &producerErrors)
+	for _, msg := range msgs {
+		var msgErr error
+		for _, producerError := range producerErrors {
+			if producerError.Msg == msg {
+				msgErr = producerError.Err
+				break
+			}
+		}
+		if msg != nil {
+			endProducerSpan(msg, true, msgErr)
+		}
+	}
+}
@@ -17,22 +17,109 @@ package kafka
 import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
 	"time"
 	_ "unsafe"
 )
 
+// kafkaReadMessageKey marks the context passed down by Reader.ReadMessage, so
+// the FetchMessage and CommitMessages calls it makes are not traced twice
+type kafkaReadMessageKey struct{}
+
+// processSpans holds the process span of the message last read from a Reader
+// or Batch, keyed by the Reader or Batch. The span ends when the next message
+// is read from it, when the message is committed or when it is closed.
+var processSpans sync.Map
+
+type kafkaProcessSpan struct {
+	ctx context.Context
+	req kafkaConsumerReq
+}
+
+func (p *kafkaProcessSpan) end() {
+	consumerInstrumenter.End(p.ctx, p.req, nil, nil)
+}
+
+func startProcessSpan(owner any, parentContext context.Context, message kafka.Message) {
+	req := kafkaConsumerReq{msg: message}
+	ctx := consumerInstrumenter.Start(parentContext, req)
+	if trace.SpanFromContext(ctx) == trace.SpanFromContext(parentContext) {
+		// no span was started
+		return
+	}
+	if previous, ok := processSpans.Swap(owner, &kafkaProcessSpan{ctx: ctx, req: req}); ok {
+		previous.(*kafkaProcessSpan).end()
+	}
+}
+
+func endProcessSpan(owner any) {
+	if previous, ok := processSpans.LoadAndDelete(owner); ok {
+		previous.(*kafkaProcessSpan).end()
+	}
+}
+
+func endCommittedProcessSpan(owner any, messages []kafka.Message) {
+	previous, ok := processSpans.Load(owner)
+	if !ok {
+		return
+	}
+	pending := previous.(*kafkaProcessSpan).req.msg
+	for _, message := range messages {
+		if message.Topic == pending.Topic && message.Partition == pending.Partition && message.Offset == pending.Offset {
+			if processSpans.CompareAndDelete(owner, previous) {
+				previous.(*kafkaProcessSpan).end()
+			}
+			return
+		}
+	}
+}
+
+// receiveMessage records the receive span of a message fetched by the reader,
+// linked to the producer, and starts the process span of that message
+func receiveMessage(reader *kafka.Reader, parentContext context.Context, startTimestamp time.Time, msg kafka.Message, err error) {
+	consumerRequest := kafkaConsumerReq{msg: msg}
+	var startOptions []trace.SpanStartOption
+	if err != nil {
+		consumerRequest.msg = kafka.Message{Topic: reader.Config().Topic}
+	} else {
+		producerContext := otel.GetTextMapPropagator().Extract(context.Background(), kafkaConsumerCarrier{message: msg})
+		if spanContext := trace.SpanContextFromContext(producerContext); spanContext.IsValid() {
+			startOptions = append(startOptions, trace.WithLinks(trace.Link{SpanContext: spanContext}))
+		}
+	}
+	receiveInstrumenter.StartAndEndWithOptions(
+		parentContext,
+		consumerRequest,
+		nil,
+		err,
+		startTimestamp,
+		time.Now(),
+		startOptions,
+		nil,
+	)
+	if err == nil {
+		startProcessSpan(reader, message.ContextWithReceived(parentContext), msg)
+	}
+}
+
 //go:linkname consumerReadMessageOnEnter github.com/segmentio/kafka-go.consumerReadMessageOnEnter
-func consumerReadMessageOnEnter(call api.CallContext, _ interface{}, ctx context.Context) {
+func consumerReadMessageOnEnter(call api.CallContext, reader *kafka.Reader, ctx context.Context) {
 	if !kafkaEnabler.Enable() {
 		return
 	}
+	endProcessSpan(reader)
 
 	instrumentationData := map[string]interface{}{
+		"reader":         reader,
 		"parentContext":  ctx,
 		"startTimestamp": time.Now(),
 	}
 	call.SetData(instrumentationData)
+	call.SetParam(1, context.WithValue(ctx, kafkaReadMessageKey{}, true))
 }
 
 //go:linkname consumerReadMessageOnExit github.com/segmentio/kafka-go.consumerReadMessageOnExit
@@ -46,17 +133,156 @@ func consumerReadMessageOnExit(call api.CallContext, message kafka.Message, err
 		return
 	}
 
+	reader := instrumentationData["reader"].(*kafka.Reader)
+	parentContext := instrumentationData["parentContext"].(context.Context)
+	startTimestamp := instrumentationData["startTimestamp"].(time.Time)
+	receiveMessage(reader, parentContext, startTimestamp, message, err)
+}
+
+//go:linkname consumerFetchMessageOnEnter github.com/segmentio/kafka-go.consumerFetchMessageOnEnter
+func consumerFetchMessageOnEnter(call api.CallContext, reader *kafka.Reader, ctx context.Context) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if ctx.Value(kafkaReadMessageKey{}) != nil {
+		return
+	}
+	endProcessSpan(reader)
+
+	instrumentationData := map[string]interface{}{
+		"reader":         reader,
+		"parentContext":  ctx,
+		"startTimestamp": time.Now(),
+	}
+	call.SetData(instrumentationData)
+}
+
+//go:linkname consumerFetchMessageOnExit github.com/segmentio/kafka-go.consumerFetchMessageOnExit
+func consumerFetchMessageOnExit(call api.CallContext, message kafka.Message, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	reader := instrumentationData["reader"].(*kafka.Reader)
 	parentContext := instrumentationData["parentContext"].(context.Context)
 	startTimestamp := instrumentationData["startTimestamp"].(time.Time)
-	endTimestamp := time.Now()
+	receiveMessage(reader, parentContext, startTimestamp, message, err)
+}
 
-	consumerRequest := kafkaConsumerReq{msg: message}
-	consumerInstrumenter.StartAndEnd(
-		parentContext,
-		consumerRequest,
-		nil,
-		err,
-		startTimestamp,
-		endTimestamp,
-	)
+//go:linkname consumerCommitMessagesOnEnter github.com/segmentio/kafka-go.consumerCommitMessagesOnEnter
+func consumerCommitMessagesOnEnter(call api.CallContext, reader *kafka.Reader, ctx context.Context, messages ...kafka.Message) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if ctx.Value(kafkaReadMessageKey{}) != nil {
+		return
+	}
+	endCommittedProcessSpan(reader, messages)
+
+	commitRequest := kafkaCommitReq{
+		topic: reader.Config().Topic,
+		msgs:  messages,
+	}
+	if len(messages) > 0 && messages[0].Topic != "" {
+		commitRequest.topic = messages[0].Topic
+	}
+	instrumentationData := map[string]interface{}{
+		"instrumentedContext": commitInstrumenter.Start(ctx, commitRequest),
+		"commitRequest":       commitRequest,
+	}
+	call.SetData(instrumentationData)
+}
+
+//go:linkname consumerCommitMessagesOnExit github.com/segmentio/kafka-go.consumerCommitMessagesOnExit
+func consumerCommitMessagesOnExit(call api.CallContext, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	instrumentedContext := instrumentationData["instrumentedContext"].(context.Context)
+	commitRequest := instrumentationData["commitRequest"].(kafkaCommitReq)
+	commitInstrumenter.End(instrumentedContext, commitRequest, nil, err)
+}
+
+//go:linkname consumerCloseOnEnter github.com/segmentio/kafka-go.consumerCloseOnEnter
+func consumerCloseOnEnter(call api.CallContext, reader *kafka.Reader) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	endProcessSpan(reader)
+}
+
+// readerReadOnEnter marks the connections the Reader fetches its batches
+// with, the messages of those batches are traced by the Reader hooks.
+//
+//go:linkname readerReadOnEnter github.com/segmentio/kafka-go.readerReadOnEnter
+func readerReadOnEnter(call api.CallContext, reader interface{}, ctx context.Context, offset int64, conn *kafka.Conn) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if conn != nil {
+		conn.OtelReaderConn = true
+	}
+}
+
+//go:linkname connReadBatchWithOnEnter github.com/segmentio/kafka-go.connReadBatchWithOnEnter
+func connReadBatchWithOnEnter(call api.CallContext, conn *kafka.Conn, cfg kafka.ReadBatchConfig) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if conn != nil && !conn.OtelReaderConn {
+		call.SetData(true)
+	}
+}
+
+//go:linkname connReadBatchWithOnExit github.com/segmentio/kafka-go.connReadBatchWithOnExit
+func connReadBatchWithOnExit(call api.CallContext, batch *kafka.Batch) {
+	if traced, ok := call.GetData().(bool); ok && traced && batch != nil {
+		batch.OtelTraced = true
+	}
+}
+
+//go:linkname batchReadMessageOnEnter github.com/segmentio/kafka-go.batchReadMessageOnEnter
+func batchReadMessageOnEnter(call api.CallContext, batch *kafka.Batch) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if batch == nil || !batch.OtelTraced {
+		return
+	}
+	endProcessSpan(batch)
+	call.SetData(batch)
+}
+
+//go:linkname batchReadMessageOnExit github.com/segmentio/kafka-go.batchReadMessageOnExit
+func batchReadMessageOnExit(call api.CallContext, message kafka.Message, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	batch, ok := call.GetData().(*kafka.Batch)
+	if !ok || err != nil {
+		return
+	}
+	startProcessSpan(batch, context.Background(), message)
+}
+
+//go:linkname batchCloseOnEnter github.com/segmentio/kafka-go.batchCloseOnEnter
+func batchCloseOnEnter(call api.CallContext, batch *kafka.Batch) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if batch != nil && batch.OtelTraced {
+		endProcessSpan(batch)
+	}
 }
@@ -20,12 +20,18 @@ import (
 )
 
 type kafkaProducerReq struct {
-	msgs  []*kafka.Message
-	topic string
-	addr  net.Addr
-	async bool
+	msgs    []*kafka.Message
+	records []*kafka.Record
+	topic   string
+	addr    net.Addr
+	async   bool
 }
 
 type kafkaConsumerReq struct {
 	msg kafka.Message
 }
+
+type kafkaCommitReq struct {
+	topic string
+	msgs  []kafka.Message
+}
@@ -16,7 +16,9 @@ package kafka
 
 import (
 	"context"
+	"fmt"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/message"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
@@ -28,29 +30,23 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 	"go.opentelemetry.io/otel/trace"
-	"os"
 )
 
 // Instrumentation enabler controller
-var kafkaEnabler = kafkaInnerEnabler{os.Getenv("OTEL_SEGMENTIO_KAFKA_ENABLED") != "false"}
+var kafkaEnabler = config.NewInstrumentationEnabler("segmentio-kafka-go")
 
 // Cache Instrumenter instances to avoid repeated creation
 var (
 	producerInstrumenter = buildKafkaProducerInstrumenter()
 	consumerInstrumenter = buildKafkaConsumerInstrumenter()
+	receiveInstrumenter  = buildKafkaReceiveInstrumenter()
+	commitInstrumenter   = buildKafkaCommitInstrumenter()
 )
 
-type kafkaInnerEnabler struct {
-	enabled bool
-}
-
-func (k kafkaInnerEnabler) Enable() bool {
-	return k.enabled
-}
-
 // KafkaProducerCarrier implements OpenTelemetry propagator carrier interface for producers
 type kafkaProducerCarrier struct {
 	messages []*kafka.Message
+	records  []*kafka.Record
 }
 
 func (carrier kafkaProducerCarrier) Get(key string) string {
@@ -64,6 +60,12 @@ func (carrier kafkaProducerCarrier) Set(key, value string) {
 			Value: []byte(value),
 		})
 	}
+	for _, record := range carrier.records {
+		record.Headers = append(record.Headers, kafka.Header{
+			Key:   key,
+			Value: []byte(value),
+		})
+	}
 }
 
 func (carrier kafkaProducerCarrier) Keys() []string {
@@ -169,11 +171,26 @@ func (getter kafkaMessageProducerAttrsGetter) GetClientId(request kafkaProducerR
 }
 
 func (getter kafkaMessageProducerAttrsGetter) GetBatchMessageCount(request kafkaProducerReq, response any) int64 {
-	return int64(len(request.msgs))
+	return int64(len(request.msgs) + len(request.records))
 }
 
 func (getter kafkaMessageProducerAttrsGetter) GetMessageHeader(request kafkaProducerReq, name string) []string {
-	return []string{}
+	var headerValues []string
+	for _, msg := range request.msgs {
+		for _, header := range msg.Headers {
+			if header.Key == name {
+				headerValues = append(headerValues, string(header.Value))
+			}
+		}
+	}
+	for _, record := range request.records {
+		for _, header := range record.Headers {
+			if header.Key == name {
+				headerValues = append(headerValues, string(header.Value))
+			}
+		}
+	}
+	return headerValues
 }
 
 // KafkaMessageConsumerAttributesGetter retrieves consumer message attributes
@@ -245,32 +262,50 @@ func (getter kafkaMessageConsumerAttrsGetter) GetMessageHeader(request kafkaCons
 	return headerValues
 }
 
-// KafkaProducerAttributesExtractor extracts producer attributes
-type kafkaProducerAttributesExtractor struct {
+// KafkaConsumerAttributesExtractor extracts consumer attributes
+type kafkaConsumerAttributesExtractor struct {
 }
 
-func (extractor *kafkaProducerAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request kafkaProducerReq) ([]attribute.KeyValue, context.Context) {
-	kafkaAttributes := []attribute.KeyValue{
-		semconv.MessagingSystemKafka,
-		semconv.MessagingDestinationNameKey.String(request.topic),
-		semconv.MessagingOperationName("publish"),
-	}
-	return append(attributes, kafkaAttributes...), parentContext
+func (extractor *kafkaConsumerAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request kafkaConsumerReq) ([]attribute.KeyValue, context.Context) {
+	return attributes, parentContext
 }
 
-func (extractor *kafkaProducerAttributesExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request kafkaProducerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+func (extractor *kafkaConsumerAttributesExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request kafkaConsumerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
 	return attributes, ctx
 }
 
-// KafkaConsumerAttributesExtractor extracts consumer attributes
-type kafkaConsumerAttributesExtractor struct {
+// KafkaCommitSpanNameExtractor names the commit span after the committed topic
+type kafkaCommitSpanNameExtractor struct{}
+
+func (extractor *kafkaCommitSpanNameExtractor) Extract(request kafkaCommitReq) string {
+	topic := request.topic
+	if topic == "" {
+		topic = "unknown"
+	}
+	return topic + " commit"
 }
 
-func (extractor *kafkaConsumerAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request kafkaConsumerReq) ([]attribute.KeyValue, context.Context) {
+// KafkaCommitAttributesExtractor extracts offset commit attributes
+type kafkaCommitAttributesExtractor struct {
+}
+
+func (extractor *kafkaCommitAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request kafkaCommitReq) ([]attribute.KeyValue, context.Context) {
+	attributes = append(attributes,
+		semconv.MessagingSystemKafka,
+		semconv.MessagingDestinationNameKey.String(request.topic),
+		semconv.MessagingOperationName("commit"),
+		semconv.MessagingOperationTypeSettle,
+	)
+	if len(request.msgs) > 1 {
+		attributes = append(attributes, semconv.MessagingBatchMessageCount(len(request.msgs)))
+	}
 	return attributes, parentContext
 }
 
-func (extractor *kafkaConsumerAttributesExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request kafkaConsumerReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+func (extractor *kafkaCommitAttributesExtractor) OnEnd(attributes []attribute.KeyValue, ctx context.Context, request kafkaCommitReq, response any, err error) ([]attribute.KeyValue, context.Context) {
+	if err != nil {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
 	return attributes, ctx
 }
 
@@ -288,10 +323,13 @@ func buildKafkaProducerInstrumenter() instrumenter.Instrumenter[kafkaProducerReq
 		}).
 		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[kafkaProducerReq]{}).
 		SetSpanStatusExtractor(&kafkaProducerStatusExtractor{}).
-		AddAttributesExtractor(&kafkaProducerAttributesExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaProducerReq, any, kafkaMessageProducerAttrsGetter]{
+			Operation: message.PUBLISH,
+		}).
+		AddOperationListeners(message.MessageMetrics("kafka-go.producer")).
 		BuildPropagatingToDownstreamInstrumenter(
 			func(request kafkaProducerReq) propagation.TextMapCarrier {
-				return kafkaProducerCarrier{messages: request.msgs}
+				return kafkaProducerCarrier{messages: request.msgs, records: request.records}
 			},
 			otel.GetTextMapPropagator(),
 		)
@@ -314,6 +352,7 @@ func buildKafkaConsumerInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq
 			Operation: message.PROCESS,
 		}).
 		AddAttributesExtractor(&kafkaConsumerAttributesExtractor{}).
+		AddOperationListeners(message.MessageMetrics("kafka-go.consumer")).
 		BuildPropagatingFromUpstreamInstrumenter(
 			func(request kafkaConsumerReq) propagation.TextMapCarrier {
 				return kafkaConsumerCarrier{message: request.msg}
@@ -321,3 +360,40 @@ func buildKafkaConsumerInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq
 			otel.GetTextMapPropagator(),
 		)
 }
+
+// Build Kafka receive instrumenter, the receive span belongs to the trace of
+// the caller and links to the producer instead of continuing its trace
+func buildKafkaReceiveInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq, any] {
+	builder := instrumenter.Builder[kafkaConsumerReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&message.MessageSpanNameExtractor[kafkaConsumerReq, any]{
+			Getter:        kafkaMessageConsumerAttrsGetter{},
+			OperationName: message.RECEIVE,
+		}).
+		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[kafkaConsumerReq]{}).
+		SetSpanStatusExtractor(&kafkaConsumerStatusExtractor{}).
+		AddAttributesExtractor(&message.MessageAttrsExtractor[kafkaConsumerReq, any, kafkaMessageConsumerAttrsGetter]{
+			Operation: message.RECEIVE,
+		}).
+		AddOperationListeners(message.MessageMetrics("kafka-go.receive")).
+		BuildInstrumenter()
+}
+
+// Build Kafka offset commit instrumenter
+func buildKafkaCommitInstrumenter() instrumenter.Instrumenter[kafkaCommitReq, any] {
+	builder := instrumenter.Builder[kafkaCommitReq, any]{}
+	return builder.Init().
+		SetInstrumentationScope(instrumentation.Scope{
+			Name:    utils.KAFKAGO_CONSUMER_SCOPE_NAME,
+			Version: version.Tag,
+		}).
+		SetSpanNameExtractor(&kafkaCommitSpanNameExtractor{}).
+		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[kafkaCommitReq]{}).
+		AddAttributesExtractor(&kafkaCommitAttributesExtractor{}).
+		AddOperationListeners(message.MessageMetrics("kafka-go.commit")).
+		BuildInstrumenter()
+}
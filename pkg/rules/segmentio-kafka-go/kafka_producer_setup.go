@@ -18,6 +18,7 @@ import (
 	"context"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
 	"github.com/segmentio/kafka-go"
+	"io"
 	_ "unsafe"
 )
 
@@ -77,3 +78,106 @@ func producerWriteMessagesOnExit(call api.CallContext, err error) {
 	// End instrumentation with results
 	producerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
 }
+
+// errRecordReader replays the error hit while reading the records of a
+// produce request, after the records read before it
+type errRecordReader struct {
+	records kafka.RecordReader
+	err     error
+}
+
+func (r errRecordReader) ReadRecord() (*kafka.Record, error) {
+	record, err := r.records.ReadRecord()
+	if err == io.EOF {
+		return nil, r.err
+	}
+	return record, err
+}
+
+// writerClientOnExit marks the clients the Writer produces its batches with.
+//
+//go:linkname writerClientOnExit github.com/segmentio/kafka-go.writerClientOnExit
+func writerClientOnExit(call api.CallContext, client *kafka.Client) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if client != nil {
+		client.OtelWriterClient = true
+	}
+}
+
+//go:linkname clientProduceOnEnter github.com/segmentio/kafka-go.clientProduceOnEnter
+func clientProduceOnEnter(call api.CallContext, client *kafka.Client, ctx context.Context, req *kafka.ProduceRequest) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+	if req == nil || req.Records == nil {
+		return
+	}
+	// Writer produces its batches through Client.Produce, the messages are
+	// already traced by WriteMessages
+	if client.OtelWriterClient {
+		return
+	}
+
+	// Read the records out of the request so that the trace context can be
+	// injected into their headers
+	var records []kafka.Record
+	var readErr error
+	for {
+		record, err := req.Records.ReadRecord()
+		if err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			break
+		}
+		records = append(records, *record)
+	}
+	recordPointers := make([]*kafka.Record, len(records))
+	for i := range records {
+		recordPointers[i] = &records[i]
+	}
+
+	producerRequest := kafkaProducerReq{
+		topic:   req.Topic,
+		addr:    req.Addr,
+		records: recordPointers,
+	}
+	if producerRequest.addr == nil {
+		producerRequest.addr = client.Addr
+	}
+	instrumentedContext := producerInstrumenter.Start(ctx, producerRequest)
+
+	instrumentationData := map[string]interface{}{
+		"instrumentedContext": instrumentedContext,
+		"producerRequest":     producerRequest,
+	}
+	call.SetData(instrumentationData)
+
+	// Hand a copy of the request with the injected records to the client
+	instrumentedReq := *req
+	instrumentedReq.Records = kafka.NewRecordReader(records...)
+	if readErr != nil {
+		instrumentedReq.Records = errRecordReader{records: instrumentedReq.Records, err: readErr}
+	}
+	call.SetParam(2, &instrumentedReq)
+}
+
+//go:linkname clientProduceOnExit github.com/segmentio/kafka-go.clientProduceOnExit
+func clientProduceOnExit(call api.CallContext, resp *kafka.ProduceResponse, err error) {
+	if !kafkaEnabler.Enable() {
+		return
+	}
+
+	instrumentationData, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
+	instrumentedContext := instrumentationData["instrumentedContext"].(context.Context)
+	producerRequest := instrumentationData["producerRequest"].(kafkaProducerReq)
+	if err == nil && resp != nil && resp.Error != nil {
+		err = resp.Error
+	}
+	producerInstrumenter.End(instrumentedContext, producerRequest, nil, err)
+}
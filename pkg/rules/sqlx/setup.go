@@ -18,23 +18,13 @@ import (
 	"context"
 	"database/sql"
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/jmoiron/sqlx"
 	"log"
-	"os"
 	_ "unsafe"
 )
 
-type sqlxInnerEnabler struct {
-	enabled bool
-}
-
-func (s sqlxInnerEnabler) Enable() bool {
-	return s.enabled
-}
-
-var sqlxEnabler = sqlxInnerEnabler{
-	enabled: os.Getenv("OTEL_INSTRUMENTATION_SQLX_ENABLED") != "false",
-}
+var sqlxEnabler = config.NewInstrumentationEnabler("sqlx")
 
 var sqlInstrumenter = BuildSqlxInstrumenter()
 
@@ -36,6 +36,10 @@ func (g sqlxAttrsGetter) GetStatement(sqlxRequest sqlxRequest) string {
 	return sqlxRequest.statement
 }
 
+func (g sqlxAttrsGetter) GetSanitizedStatement(sqlxRequest sqlxRequest) string {
+	return db.SanitizeSql(sqlxRequest.driverName, sqlxRequest.statement)
+}
+
 func (g sqlxAttrsGetter) GetCollection(_ sqlxRequest) string {
 	// TBD: We need to implement retrieving the collection later.
 	return ""
@@ -16,9 +16,9 @@ package trpc
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api-semconv/instrumenter/rpc"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/instrumenter"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/utils"
 	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/version"
@@ -30,15 +30,7 @@ import (
 	"trpc.group/trpc-go/trpc-go/codec"
 )
 
-type trpcInnerEnabler struct {
-	enabled bool
-}
-
-func (t trpcInnerEnabler) Enable() bool {
-	return t.enabled
-}
-
-var trpcEnabler = trpcInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_TRPC_ENABLED") != "false"}
+var trpcEnabler = config.NewInstrumentationEnabler("trpc")
 
 type trpcClientAttrsGetter struct {
 }
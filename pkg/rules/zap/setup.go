@@ -15,24 +15,16 @@
 package zap
 
 import (
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-type zapInnerEnabler struct {
-	enabled bool
-}
-
-func (z zapInnerEnabler) Enable() bool {
-	return z.enabled
-}
-
-var zapEnabler = zapInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_ZAP_ENABLED") != "false"}
+var zapEnabler = config.NewInstrumentationEnabler("zap")
 
 //go:linkname zapLogWriteOnEnter go.uber.org/zap/zapcore.zapLogWriteOnEnter
 func zapLogWriteOnEnter(call api.CallContext, ce *zapcore.CheckedEntry, fields ...zap.Field) {
@@ -15,23 +15,15 @@
 package zerolog
 
 import (
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	"github.com/alibaba/loongsuite-go-agent/pkg/inst-api/config"
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type zeroLogInnerEnabler struct {
-	enabled bool
-}
-
-func (z zeroLogInnerEnabler) Enable() bool {
-	return z.enabled
-}
-
-var zeroLogEnabler = zeroLogInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_ZEROLOG_ENABLED") != "false"}
+var zeroLogEnabler = config.NewInstrumentationEnabler("zerolog")
 
 //go:linkname zeroLogWriteOnEnter github.com/rs/zerolog.zeroLogWriteOnEnter
 func zeroLogWriteOnEnter(call api.CallContext, ce *zerolog.Event, msg string) {
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/panjf2000/ants/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var tracer = otel.Tracer("ants-test")
+
+func work(name string) {
+	_, span := tracer.Start(context.Background(), name)
+	span.End()
+}
+
+func main() {
+	// A single worker runs every task, the worker goroutine is created while
+	// the first task is submitted
+	pool, err := ants.NewPool(1)
+	if err != nil {
+		panic(err)
+	}
+	defer pool.Release()
+	var wg sync.WaitGroup
+	submit := func(parent, task string) {
+		_, span := tracer.Start(context.Background(), parent)
+		defer span.End()
+		wg.Add(1)
+		if err := pool.Submit(func() {
+			defer wg.Done()
+			work(task)
+		}); err != nil {
+			panic(err)
+		}
+		wg.Wait()
+	}
+	submit("submit-a", "task-a")
+	submit("submit-b", "task-b")
+
+	funcPool, err := ants.NewPoolWithFunc(1, func(arg interface{}) {
+		defer wg.Done()
+		work(arg.(string))
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer funcPool.Release()
+	invoke := func(parent, task string) {
+		_, span := tracer.Start(context.Background(), parent)
+		defer span.End()
+		wg.Add(1)
+		if err := funcPool.Invoke(task); err != nil {
+			panic(err)
+		}
+		wg.Wait()
+	}
+	invoke("invoke-a", "invoke-task-a")
+	invoke("invoke-b", "invoke-task-b")
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		expected := [][2]string{
+			{"submit-a", "task-a"},
+			{"submit-b", "task-b"},
+			{"invoke-a", "invoke-task-a"},
+			{"invoke-b", "invoke-task-b"},
+		}
+		for i, names := range expected {
+			verifier.Assert(len(stubs[i]) == 2, "Expect 2 spans in trace %d, got %d", i, len(stubs[i]))
+			verifier.Assert(stubs[i][0].Name == names[0], "Expect root span %s, got %s", names[0], stubs[i][0].Name)
+			verifier.Assert(stubs[i][1].Name == names[1], "Expect task span %s, got %s", names[1], stubs[i][1].Name)
+			verifier.Assert(stubs[i][1].Parent.SpanID() == stubs[i][0].SpanContext.SpanID(),
+				"Expect %s to be child of %s", names[1], names[0])
+		}
+	}, 4)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupMount() {
+	orders := chi.NewRouter()
+	orders.Get("/{orderID}/items/{itemID}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(chi.URLParam(r, "itemID")))
+	})
+	api := chi.NewRouter()
+	api.Mount("/orders", orders)
+	r := chi.NewRouter()
+	r.Mount("/api", api)
+	_ = http.ListenAndServe("127.0.0.1:8084", r)
+}
+
+func main() {
+	go setupMount()
+	time.Sleep(3 * time.Second)
+	resp, err := http.Get("http://127.0.0.1:8084/api/orders/7/items/3")
+	if err != nil {
+		panic(err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyHttpClientAttributes(stubs[0][0], "GET", "GET", "http://127.0.0.1:8084/api/orders/7/items/3", "http", "1.1", "tcp", "ipv4", "", "127.0.0.1", 200, 0, 8084)
+		verifier.VerifyHttpServerAttributes(stubs[0][1], "/api/orders/{orderID}/items/{itemID}", "GET", "http", "tcp", "ipv4", "", "127.0.0.1:8084", "Go-http-client/1.1", "http", "/api/orders/7/items/3", "", "/api/orders/{orderID}/items/{itemID}", 200)
+	}, 1)
+}
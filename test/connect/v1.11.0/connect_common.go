@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"connectrpc.com/connect"
+)
+
+const (
+	greetProcedure       = "/greet.v1.GreetService/Greet"
+	greetStreamProcedure = "/greet.v1.GreetService/GreetStream"
+)
+
+type GreetRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type GreetResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+// jsonCodec marshals plain structs, so that no generated code is needed.
+type jsonCodec struct{}
+
+func (c jsonCodec) Name() string { return "json" }
+
+func (c jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (c jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func setupServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(greetProcedure, connect.NewUnaryHandler(greetProcedure,
+		func(ctx context.Context, req *connect.Request[GreetRequest]) (*connect.Response[GreetResponse], error) {
+			if req.Msg.Name == "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+			}
+			return connect.NewResponse(&GreetResponse{Greeting: "Hello, " + req.Msg.Name}), nil
+		}, connect.WithCodec(jsonCodec{})))
+	mux.Handle(greetStreamProcedure, connect.NewServerStreamHandler(greetStreamProcedure,
+		func(ctx context.Context, req *connect.Request[GreetRequest], stream *connect.ServerStream[GreetResponse]) error {
+			for i := 0; i < req.Msg.Count; i++ {
+				if err := stream.Send(&GreetResponse{Greeting: "Hello, " + req.Msg.Name + " " + strconv.Itoa(i)}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, connect.WithCodec(jsonCodec{})))
+	return httptest.NewServer(mux)
+}
+
+func newGreetClient(baseURL string) *connect.Client[GreetRequest, GreetResponse] {
+	return connect.NewClient[GreetRequest, GreetResponse](http.DefaultClient, baseURL+greetProcedure, connect.WithCodec(jsonCodec{}))
+}
+
+func newGreetStreamClient(baseURL string) *connect.Client[GreetRequest, GreetResponse] {
+	return connect.NewClient[GreetRequest, GreetResponse](http.DefaultClient, baseURL+greetStreamProcedure, connect.WithCodec(jsonCodec{}))
+}
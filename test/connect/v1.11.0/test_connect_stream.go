@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	server := setupServer()
+	defer server.Close()
+	client := newGreetStreamClient(server.URL)
+	stream, err := client.CallServerStream(context.Background(), connect.NewRequest(&GreetRequest{Name: "connect", Count: 3}))
+	if err != nil {
+		panic(err)
+	}
+	received := 0
+	for stream.Receive() {
+		fmt.Println(stream.Msg().Greeting)
+		received++
+	}
+	if err = stream.Err(); err != nil {
+		panic(err)
+	}
+	if err = stream.Close(); err != nil {
+		panic(err)
+	}
+	if received != 3 {
+		panic(fmt.Sprintf("expect 3 messages, got %d", received))
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyRpcClientAttributes(stubs[0][0], "greet.v1.GreetService/GreetStream", "connect_rpc", "greet.v1.GreetService", "GreetStream")
+		// the handler is recorded on the span of the http server
+		verifier.Assert(len(stubs[0]) == 3, "Expect 3 spans in the trace, got %d", len(stubs[0]))
+		verifier.VerifyRpcServerAttributes(stubs[0][2], "greet.v1.GreetService/GreetStream", "connect_rpc", "greet.v1.GreetService", "GreetStream")
+		verifier.Assert(stubs[0][2].Parent.SpanID() == stubs[0][1].SpanContext.SpanID(), "Expect the http server span to be the child of the http client span")
+	}, 1)
+}
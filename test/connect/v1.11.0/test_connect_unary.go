@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	server := setupServer()
+	defer server.Close()
+	client := newGreetClient(server.URL)
+	resp, err := client.CallUnary(context.Background(), connect.NewRequest(&GreetRequest{Name: "connect"}))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(resp.Msg.Greeting)
+	_, err = client.CallUnary(context.Background(), connect.NewRequest(&GreetRequest{}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		panic(fmt.Sprintf("expect invalid_argument, got %v", err))
+	}
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for i, stub := range stubs {
+			verifier.VerifyRpcClientAttributes(stub[0], "greet.v1.GreetService/Greet", "connect_rpc", "greet.v1.GreetService", "Greet")
+			// the handler is recorded on the span of the http server
+			verifier.Assert(len(stub) == 3, "Expect 3 spans in the trace, got %d", len(stub))
+			verifier.VerifyRpcServerAttributes(stub[2], "greet.v1.GreetService/Greet", "connect_rpc", "greet.v1.GreetService", "Greet")
+			verifier.Assert(stub[2].Parent.SpanID() == stub[1].SpanContext.SpanID(), "Expect the http server span to be the child of the http client span")
+			if i == 1 {
+				for _, span := range []tracetest.SpanStub{stub[0], stub[2]} {
+					code := verifier.GetAttribute(span.Attributes, "rpc.connect_rpc.error_code").AsString()
+					verifier.Assert(code == "invalid_argument", "Expect error code to be invalid_argument, got %s", code)
+				}
+			}
+		}
+	}, 2)
+}
@@ -82,6 +82,10 @@ func dbTransaction() {
 }
 
 func main() {
+	if os.Getenv("OTEL_INSTRUMENTATION_DATABASESQL_ROWS_TRACING") == "fetch" {
+		dbRowsTracing()
+		return
+	}
 	dbAccess()
 	dbFetching()
 	dbModify()
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func dbRowsTracing() {
+	ctx := context.Background()
+	db, err := sql.Open("mysql",
+		"test:test@tcp(127.0.0.1:"+os.Getenv("MYSQL_PORT")+")/test")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS users`); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS users (id char(255), name VARCHAR(255), age INTEGER)`); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO users (id, name, age) VALUES ( ?, ?, ?), ( ?, ?, ?)`, "0", "foo", 10, "1", "bar", 11); err != nil {
+		log.Fatal(err)
+	}
+	rows, err := db.QueryContext(ctx, "select id, name from users where age > ?", 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for rows.Next() {
+		var (
+			id   int
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatal(err)
+	}
+	_ = rows.Close()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyDbAttributes(stubs[3][0], "select users", "mysql", "127.0.0.1", "select id, name from users where age > ?", "select", "users", nil)
+		fetch := stubs[3][1]
+		verifier.VerifyDbAttributes(fetch, "fetch users", "mysql", "127.0.0.1", "select id, name from users where age > ?", "fetch", "users", nil)
+		verifier.Assert(fetch.Parent.SpanID() == stubs[3][0].SpanContext.SpanID(), "Expect fetch span to be a child of the query span")
+		returnedRows := verifier.GetAttribute(fetch.Attributes, "db.response.returned_rows").AsInt64()
+		verifier.Assert(returnedRows == 2, "Expect 2 returned rows, got %d", returnedRows)
+	}, 4)
+}
@@ -27,6 +27,7 @@ func init() {
 	TestCases = append(TestCases,
 		NewGeneralTestCase("databasesql-mysql-8x", "databasesql", "", "", "1.18", "", TestMySql8x),
 		NewGeneralTestCase("databasesql-mysql-5x", "databasesql", "", "", "1.18", "", TestMySql5x),
+		NewGeneralTestCase("databasesql-mysql-rows-tracing", "databasesql", "", "", "1.18", "", TestMySqlRowsTracing),
 	)
 }
 
@@ -50,6 +51,16 @@ func TestMySql8x(t *testing.T, env ...string) {
 	RunApp(t, "mysql", env...)
 }
 
+func TestMySqlRowsTracing(t *testing.T, env ...string) {
+	_, mysqlPort := init8xMySqlContainer()
+
+	UseApp("databasesql/mysql")
+	RunGoBuild(t, "go", "build")
+	env = append(env, "MYSQL_PORT="+mysqlPort.Port())
+	env = append(env, "OTEL_INSTRUMENTATION_DATABASESQL_ROWS_TRACING=fetch")
+	RunApp(t, "mysql", env...)
+}
+
 func init5xMySqlContainer() (testcontainers.Container, nat.Port) {
 	ctx := context.Background()
 	mysqlContainer, err := mysql.Run(ctx, "mysql:5.6")
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/sync/errgroup"
+)
+
+var tracer = otel.Tracer("errgroup-test")
+
+func work(name string) func() error {
+	return func() error {
+		_, span := tracer.Start(context.Background(), name)
+		span.End()
+		return nil
+	}
+}
+
+func main() {
+	_, span := tracer.Start(context.Background(), "parent")
+	g := &errgroup.Group{}
+	g.SetLimit(1)
+	g.Go(work("go"))
+	if err := g.Wait(); err != nil {
+		panic(err)
+	}
+	if !g.TryGo(work("try-go")) {
+		panic("expect TryGo to start the function")
+	}
+	if err := g.Wait(); err != nil {
+		panic(err)
+	}
+	span.End()
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 3, "Expect 3 spans, got %d", len(stubs[0]))
+		verifier.Assert(stubs[0][0].Name == "parent", "Expect root span parent, got %s", stubs[0][0].Name)
+		for i, name := range []string{"go", "try-go"} {
+			child := stubs[0][i+1]
+			verifier.Assert(child.Name == name, "Expect span %s, got %s", name, child.Name)
+			verifier.Assert(child.Parent.SpanID() == stubs[0][0].SpanContext.SpanID(), "Expect %s to be child of parent", name)
+		}
+	}, 1)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+)
+
+const errgroup_dependency_name = "golang.org/x/sync"
+const errgroup_module_name = "errgroup"
+
+// There is no errgroup rule, every task of a Group runs on a new goroutine,
+// which inherits the context of the goroutine calling Group.Go. The test
+// guards that, so there is nothing to muzzle check.
+func init() {
+	TestCases = append(TestCases, NewGeneralTestCase("test_errgroup", errgroup_module_name, "v0.1.0", "", "1.18", "", TestErrgroup),
+		NewLatestDepthTestCase("test_errgroup", errgroup_dependency_name, errgroup_module_name, "v0.1.0", "", "1.18", "", TestErrgroup))
+}
+
+func TestErrgroup(t *testing.T, env ...string) {
+	UseApp("errgroup/v0.1.0")
+	RunGoBuild(t, "go", "build", "test_errgroup.go")
+	RunApp(t, "test_errgroup", env...)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// child reports the trace it continues, so that the parent can verify it
+func child() {
+	_, span := otel.Tracer("child").Start(context.Background(), "child-work")
+	span.End()
+	parent := span.(sdktrace.ReadOnlySpan).Parent()
+	// Once the child serves its own callers, its root spans start new traces
+	_, serve := otel.Tracer("child").Start(context.Background(), "child-serve",
+		trace.WithSpanKind(trace.SpanKindServer))
+	serve.End()
+	_, after := otel.Tracer("child").Start(context.Background(), "child-after-serve")
+	after.End()
+	fmt.Printf("%s %s %s %s\n", span.SpanContext().TraceID(), parent.SpanID(),
+		baggage.FromContext(context.Background()).Member("tenant").Value(),
+		after.SpanContext().TraceID())
+	os.Exit(3)
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "child" {
+		child()
+	}
+	member, _ := baggage.NewMember("tenant", "loongsuite")
+	bag, _ := baggage.New(member)
+	baggage.ContextWithBaggage(context.Background(), bag)
+
+	out, err := exec.Command(os.Args[0], "child", "--token", "s3cret").Output()
+	if err == nil {
+		log.Fatalf("expect child to exit with code 3")
+	}
+	if err = exec.Command("/nonexistent/otel-missing").Run(); err == nil {
+		log.Fatalf("expect missing command to fail")
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		span := stubs[0][0]
+		verifier.Assert(span.Name == "exec "+filepath.Base(os.Args[0]),
+			"Expect span name to be exec <binary>, got %s", span.Name)
+		command := verifier.GetAttribute(span.Attributes, "process.command").AsString()
+		verifier.Assert(command == os.Args[0], "Expect command to be %s, got %s", os.Args[0], command)
+		args := verifier.GetAttribute(span.Attributes, "process.command_args").AsStringSlice()
+		verifier.Assert(strings.Join(args[1:], " ") == "child --token REDACTED", "Expect args to be redacted, got %v", args)
+		exitCode := verifier.GetAttribute(span.Attributes, "process.exit.code").AsInt64()
+		verifier.Assert(exitCode == 3, "Expect exit code to be 3, got %d", exitCode)
+		pid := verifier.GetAttribute(span.Attributes, "process.pid").AsInt64()
+		verifier.Assert(pid > 0, "Expect pid to be recorded, got %d", pid)
+
+		fields := strings.Fields(string(out))
+		verifier.Assert(len(fields) == 4, "Expect child to report its trace, got %q", string(out))
+		verifier.Assert(fields[0] == span.SpanContext.TraceID().String(),
+			"Expect child to continue trace %s, got %s", span.SpanContext.TraceID(), fields[0])
+		verifier.Assert(fields[1] == span.SpanContext.SpanID().String(),
+			"Expect child span parent to be %s, got %s", span.SpanContext.SpanID(), fields[1])
+		verifier.Assert(fields[2] == "loongsuite", "Expect child baggage to be loongsuite, got %s", fields[2])
+		verifier.Assert(fields[3] != fields[0], "Expect child root span after serving to start a new trace")
+
+		missing := stubs[1][0]
+		verifier.Assert(missing.Name == "exec otel-missing", "Expect span name to be exec otel-missing, got %s", missing.Name)
+		verifier.Assert(missing.Status.Code == 1, "Expect span status to be error, got %v", missing.Status)
+		verifier.Assert(verifier.GetAttribute(missing.Attributes, "process.exit.code").Type() == 0,
+			"Expect no exit code for a command that failed to start")
+	}, 2)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const topicName = "test-topic"
+
+func main() {
+	ctx := context.Background()
+
+	// Run an in-process Kafka cluster
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, topicName))
+	if err != nil {
+		panic(err)
+	}
+	defer cluster.Close()
+
+	producer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.DefaultProduceTopic(topicName),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer producer.Close()
+
+	// Produce one record synchronously and one through a promise
+	if err = producer.ProduceSync(ctx, &kgo.Record{Value: []byte("hello world1")}).FirstErr(); err != nil {
+		panic(err)
+	}
+	produced := make(chan error, 1)
+	producer.Produce(ctx, &kgo.Record{Topic: topicName, Value: []byte("hello world2")}, func(_ *kgo.Record, err error) {
+		produced <- err
+	})
+	if err = <-produced; err != nil {
+		panic(err)
+	}
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics(topicName),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer consumer.Close()
+
+	// Process both records
+	pollCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	polls := 0
+	for consumed := 0; consumed < 2; {
+		fetches := consumer.PollFetches(pollCtx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			panic(errs[0].Err)
+		}
+		if fetches.NumRecords() > 0 {
+			polls++
+		}
+		fetches.EachRecord(func(record *kgo.Record) {
+			consumed++
+		})
+	}
+
+	// Every record is published and processed in the same trace, the receive
+	// spans belong to the trace of the poller and link to the publish span
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		publishSpans := map[trace.SpanID]bool{}
+		for _, stub := range stubs {
+			if len(stub) == 2 {
+				verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", topicName, "kafka")
+				verifier.VerifyMQConsumeAttributes(stub[1], "", "", "", "process", topicName, "kafka")
+				verifier.Assert(stub[1].Parent.SpanID() == stub[0].SpanContext.SpanID(),
+					"Expect process span to be a child of the publish span")
+				publishSpans[stub[0].SpanContext.SpanID()] = true
+			}
+		}
+		verifier.Assert(len(publishSpans) == 2, "Expect 2 published records, got %d", len(publishSpans))
+		// Every poll returning records gets a single receive span
+		receiveSpans, receivedRecords := 0, int64(0)
+		for _, stub := range stubs {
+			if len(stub) == 1 {
+				verifier.VerifyMQConsumeAttributes(stub[0], "", "", "", "receive", topicName, "kafka")
+				count := verifier.GetAttribute(stub[0].Attributes, "messaging.batch.message_count").AsInt64()
+				verifier.Assert(len(stub[0].Links) == int(count), "Expect receive span to link to %d publish spans, got %v", count, stub[0].Links)
+				for _, link := range stub[0].Links {
+					verifier.Assert(publishSpans[link.SpanContext.SpanID()], "Expect receive span to link to a publish span, got %v", link)
+				}
+				receiveSpans++
+				receivedRecords += count
+			}
+		}
+		verifier.Assert(receiveSpans == polls, "Expect %d receive spans, got %d", polls, receiveSpans)
+		verifier.Assert(receivedRecords == 2, "Expect 2 received records, got %d", receivedRecords)
+	}, 2+polls)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+)
+
+const franzGoModuleName = "franz-go"
+
+const franzGoDependencyName = "github.com/twmb/franz-go"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("franz-go-basic-test", franzGoModuleName, "1.13.0", "", "1.21.0", "", TestFranzGoKafka),
+		NewMuzzleTestCase("franz-go-muzzle-test", franzGoDependencyName, franzGoModuleName, "v1.18.1", "", "1.21.0", "", []string{"go", "build", "test_franz_basic.go"}),
+		NewLatestDepthTestCase("franz-go-latestdepth-test", franzGoDependencyName, franzGoModuleName, "v1.18.1", "", "1.21.0", "", TestFranzGoKafka),
+	)
+}
+
+func TestFranzGoKafka(t *testing.T, env ...string) {
+	UseApp("franz-go/v1.18.1")
+	RunGoBuild(t, "go", "build", "test_franz_basic.go")
+	RunApp(t, "test_franz_basic", env...)
+}
@@ -94,10 +94,10 @@ func main() {
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
 		verifier.VerifyDbAttributes(stubs[0][0], "CREATE KEYSPACE", "cassandra", host, "CREATE KEYSPACE IF NOT EXISTS cassandra WITH REPLICATION = { 'class' : 'SimpleStrategy', 'replication_factor' : '1' };", "CREATE KEYSPACE", "", nil)
 		verifier.VerifyDbAttributes(stubs[1][0], "CREATE TABLE", "cassandra", host, "CREATE TABLE IF NOT EXISTS cassandra.shopping_cart (userid text PRIMARY KEY,item_count int,last_update_timestamp timestamp);", "CREATE TABLE", "", nil)
-		verifier.VerifyDbAttributes(stubs[2][0], "INSERT", "cassandra", host, "INSERT INTO cassandra.shopping_cart\n(userid, item_count, last_update_timestamp)\nVALUES ('9876', 2, toTimeStamp(now()));", "INSERT", "", nil)
+		verifier.VerifyDbAttributes(stubs[2][0], "INSERT", "cassandra", host, "INSERT INTO cassandra.shopping_cart\n(userid, item_count, last_update_timestamp)\nVALUES (?, ?, toTimeStamp(now()));", "INSERT", "", nil)
 		verifier.VerifyDbAttributes(stubs[3][0], "SELECT", "cassandra", host, "SELECT userid FROM cassandra.shopping_cart;", "SELECT", "", nil)
-		verifier.VerifyDbAttributes(stubs[4][0], "UPDATE", "cassandra", host, "update cassandra.shopping_cart \nSET item_count = 6, last_update_timestamp = toTimeStamp(now()) \nWHERE userid = '1234';", "UPDATE", "", nil)
-		verifier.VerifyDbAttributes(stubs[5][0], "DELETE", "cassandra", host, "delete FROM cassandra.shopping_cart \nWHERE userid = '1234';", "DELETE", "", nil)
+		verifier.VerifyDbAttributes(stubs[4][0], "UPDATE", "cassandra", host, "update cassandra.shopping_cart \nSET item_count = ?, last_update_timestamp = toTimeStamp(now()) \nWHERE userid = ?;", "UPDATE", "", nil)
+		verifier.VerifyDbAttributes(stubs[5][0], "DELETE", "cassandra", host, "delete FROM cassandra.shopping_cart \nWHERE userid = ?;", "DELETE", "", nil)
 		verifier.VerifyDbAttributes(stubs[6][0], "DROP TABLE", "cassandra", host, "DROP table IF EXISTS cassandra.shopping_cart;", "DROP TABLE", "", nil)
 		verifier.VerifyDbAttributes(stubs[7][0], "DROP KEYSPACE", "cassandra", host, "DROP KEYSPACE IF EXISTS cassandra;", "DROP KEYSPACE", "", nil)
 	}, 1)
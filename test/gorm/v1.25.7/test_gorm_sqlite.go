@@ -0,0 +1,72 @@
+// Copyright (c) 2024 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"log"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/glebarez/sqlite"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+)
+
+var db *gorm.DB
+
+type User struct {
+	ID   uint
+	Name string
+	Age  uint8
+}
+
+func TestRaw() {
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS users (id INTEGER PRIMARY KEY, name VARCHAR(255), age INTEGER)`).Error; err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+func TestCreate() {
+	user := User{Name: "opentelemetry", Age: 18}
+	if err := db.Create(&user).Error; err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+func TestQuery() {
+	var user User
+	if err := db.First(&user).Error; err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+func main() {
+	tmpDB, err := gorm.Open(sqlite.Open("file:test.db?mode=memory"), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("open db error: %v \n", err)
+	}
+	db = tmpDB
+	TestRaw()
+	TestCreate()
+	TestQuery()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyDbAttributes(stubs[2][0], "raw", "sqlite", "", "CREATE TABLE", "raw", "", nil)
+		verifier.VerifyDbAttributes(stubs[4][0], "create", "sqlite", "", "INSERT INTO `users`", "create", "", nil)
+		verifier.VerifyDbAttributes(stubs[5][0], "query", "sqlite", "", "users", "query", "", nil)
+		for _, i := range []int{2, 4, 5} {
+			namespace := verifier.GetAttribute(stubs[i][0].Attributes, "db.namespace").AsString()
+			verifier.Assert(namespace == "test.db", "Expect db namespace to be test.db, got %s", namespace)
+		}
+	}, 1)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	restPort = 8881
+	rpcAddr  = "127.0.0.1:8882"
+)
+
+func setupRestServer() {
+	logx.Disable()
+	server := rest.MustNewServer(rest.RestConf{
+		ServiceConf: service.ServiceConf{Name: "gozero-rest"},
+		Host:        "127.0.0.1",
+		Port:        restPort,
+		Middlewares: rest.MiddlewaresConf{Trace: true, Recover: true},
+	})
+	server.AddRoute(rest.Route{
+		Method: http.MethodGet,
+		Path:   "/users/:id",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		},
+	})
+	server.Start()
+}
+
+func setupRpcServer() {
+	logx.Disable()
+	server := zrpc.MustNewServer(zrpc.RpcServerConf{
+		ServiceConf: service.ServiceConf{Name: "gozero-rpc"},
+		ListenOn:    rpcAddr,
+		Middlewares: zrpc.ServerMiddlewaresConf{Trace: true, Recover: true},
+	}, func(s *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	})
+	server.Start()
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func main() {
+	go setupRestServer()
+	time.Sleep(3 * time.Second)
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/users/42", restPort))
+	if err != nil {
+		panic(err)
+	}
+	_ = resp.Body.Close()
+	// the trace middleware of go-zero writes the server span to the response
+	traceparent := resp.Header.Get("Traceparent")
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 2, "Expect 2 spans without the go-zero one, got %d", len(stubs[0]))
+		server := stubs[0][1].SpanContext
+		verifier.Assert(strings.Contains(traceparent, server.TraceID().String()+"-"+server.SpanID().String()),
+			"Expect the response to carry the server span, got %q", traceparent)
+		verifier.VerifyHttpClientAttributes(stubs[0][0], "GET", "GET", "http://127.0.0.1:8881/users/42", "http", "1.1", "tcp", "ipv4", "", "127.0.0.1", 200, 0, 8881)
+		verifier.VerifyHttpServerAttributes(stubs[0][1], "/users/:id", "GET", "http", "tcp", "ipv4", "", "127.0.0.1:8881", "Go-http-client/1.1", "http", "/users/42", "", "/users/:id", 200)
+	}, 1)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/zeromicro/go-zero/zrpc"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	go setupRpcServer()
+	time.Sleep(3 * time.Second)
+	client := zrpc.MustNewClient(zrpc.RpcClientConf{
+		Endpoints:   []string{rpcAddr},
+		Middlewares: zrpc.ClientMiddlewaresConf{Trace: true},
+	})
+	resp, err := grpc_health_v1.NewHealthClient(client.Conn()).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		panic(err)
+	}
+	verifier.Assert(resp.Status == grpc_health_v1.HealthCheckResponse_SERVING, "Unexpected health status %v", resp.Status)
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 2, "Expect 2 spans without the go-zero ones, got %d", len(stubs[0]))
+		verifier.VerifyRpcClientAttributes(stubs[0][0], "/grpc.health.v1.Health/Check", "grpc", "/grpc.health.v1.Health", "Check")
+		verifier.VerifyRpcServerAttributes(stubs[0][1], "/grpc.health.v1.Health/Check", "grpc", "/grpc.health.v1.Health", "Check")
+	}, 1)
+}
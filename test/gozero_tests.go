@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+)
+
+const gozero_dependency_name = "github.com/zeromicro/go-zero"
+const gozero_module_name = "gozero"
+
+func init() {
+	TestCases = append(TestCases, NewGeneralTestCase("test_gozero_rest", gozero_module_name, "v1.5.0", "", "1.19", "", TestGoZeroRest),
+		NewGeneralTestCase("test_gozero_rpc", gozero_module_name, "v1.5.0", "", "1.19", "", TestGoZeroRpc),
+		NewLatestDepthTestCase("test_gozero_rest", gozero_dependency_name, gozero_module_name, "v1.5.0", "", "1.19", "", TestGoZeroRest),
+		NewMuzzleTestCase("test_gozero_rest_muzzle", gozero_dependency_name, gozero_module_name, "v1.5.0", "", "1.19", "", []string{"go", "build", "test_gozero_rest.go", "gozero_common.go"}))
+}
+
+func TestGoZeroRest(t *testing.T, env ...string) {
+	UseApp("gozero/v1.5.0")
+	RunGoBuild(t, "go", "build", "test_gozero_rest.go", "gozero_common.go")
+	RunApp(t, "test_gozero_rest", env...)
+}
+
+func TestGoZeroRpc(t *testing.T, env ...string) {
+	UseApp("gozero/v1.5.0")
+	RunGoBuild(t, "go", "build", "test_gozero_rpc.go", "gozero_common.go")
+	RunApp(t, "test_gozero_rpc", env...)
+}
@@ -57,7 +57,7 @@ func main() {
 	// use a http client to request to the server
 	requestServer()
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
-		verifier.VerifyHttpClientAttributes(stubs[0][0], "GET", "GET", "http://127.0.0.1:8080"+"/iris", "http", "1.1", "tcp", "ipv4", "", "127.0.0.1:8080", 200, 0, 8080)
+		verifier.VerifyHttpClientAttributes(stubs[0][0], "GET", "GET", "http://127.0.0.1:8080"+"/iris", "http", "1.1", "tcp", "ipv4", "", "127.0.0.1", 200, 0, 8080)
 		verifier.VerifyHttpServerAttributes(stubs[0][1], "GET /iris", "GET", "http", "tcp", "ipv4", "", "127.0.0.1:8080", "Go-http-client/1.1", "http", "/iris", "", "/iris", 200)
 		if stubs[0][1].Parent.TraceID().String() != stubs[0][0].SpanContext.TraceID().String() {
 			log.Fatal("span 1 should be child of span 0")
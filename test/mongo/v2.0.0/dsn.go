@@ -12,9 +12,12 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package mongo
+package main
 
-type mongoRequest struct {
-	CommandName string
-	Host        string
-}
+import "os"
+
+const (
+	db = "otel_database"
+)
+
+var dsn = "mongodb://127.0.0.1:" + os.Getenv("MONGO_PORT")
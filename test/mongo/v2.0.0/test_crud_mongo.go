@@ -0,0 +1,96 @@
+// Copyright (c) 2024 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// User model.
+type User struct {
+	ID   bson.ObjectID `bson:"_id,omitempty"`
+	Name string        `bson:"name"`
+	Age  int           `bson:"age"`
+}
+
+func main() {
+	client, err := mongo.Connect(options.Client().ApplyURI(dsn))
+	if err != nil {
+		panic(fmt.Sprintf("connect mongodb error %v \n", err))
+	}
+	ctx := context.Background()
+	collection := client.Database(db).Collection("users")
+	objectID, err := bson.ObjectIDFromHex("637334579a3d0cf34c31d08f")
+	if err != nil {
+		panic(err)
+	}
+	_, err = collection.InsertOne(ctx, &User{ID: objectID, Name: "Elza2", Age: 18})
+	if err != nil {
+		log.Printf("failed to insert: %v", err)
+	}
+	_, err = collection.InsertMany(ctx, []any{
+		&User{Name: "Alice", Age: 20},
+		&User{Name: "Bob", Age: 21},
+		&User{Name: "Carol", Age: 22},
+	})
+	if err != nil {
+		log.Printf("failed to insert many: %v", err)
+	}
+	var user User
+	err = collection.FindOne(ctx, bson.D{{Key: "name", Value: "Elza2"}}).Decode(&user)
+	if err != nil {
+		log.Printf("failed to query: %v", err)
+	}
+	_, err = collection.UpdateByID(ctx, objectID, bson.D{{Key: "$set", Value: bson.D{{Key: "age", Value: 22}}}})
+	if err != nil {
+		log.Printf("failed to update: %v", err)
+	}
+	_, err = collection.DeleteOne(ctx, bson.D{{Key: "name", Value: "Elza2"}})
+	if err != nil {
+		log.Printf("failed to delete: %v", err)
+	}
+	// Unknown commands are rejected by the server with code 59
+	err = client.Database(db).RunCommand(ctx, bson.D{{Key: "otelUnknownCommand", Value: 1}}).Err()
+	if err == nil {
+		log.Printf("expect unknown command to fail")
+	}
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyDbAttributes(stubs[0][0], "insert", "mongodb", "127.0.0.1", "insert", "insert", "users", nil)
+		verifier.VerifyDbAttributes(stubs[1][0], "insert", "mongodb", "127.0.0.1", "insert", "insert", "users", nil)
+		verifier.VerifyDbAttributes(stubs[2][0], "find", "mongodb", "127.0.0.1", "find", "find", "users", nil)
+		verifier.VerifyDbAttributes(stubs[3][0], "update", "mongodb", "127.0.0.1", "update", "update", "users", nil)
+		verifier.VerifyDbAttributes(stubs[4][0], "delete", "mongodb", "127.0.0.1", "delete", "delete", "users", nil)
+		verifier.VerifyDbAttributes(stubs[5][0], "otelUnknownCommand", "mongodb", "127.0.0.1", "otelUnknownCommand", "otelUnknownCommand", "", nil)
+		for _, stub := range stubs {
+			namespace := verifier.GetAttribute(stub[0].Attributes, "db.namespace").AsString()
+			verifier.Assert(namespace == db, "Expect db namespace to be %s, got %s", db, namespace)
+		}
+		batchSize := verifier.GetAttribute(stubs[0][0].Attributes, "db.operation.batch.size")
+		verifier.Assert(batchSize.Type() == 0, "Expect single insert not to be a batch, got %v", batchSize.AsInterface())
+		batchSize = verifier.GetAttribute(stubs[1][0].Attributes, "db.operation.batch.size")
+		verifier.Assert(batchSize.AsInt64() == 3, "Expect batch size to be 3, got %d", batchSize.AsInt64())
+		statusCode := verifier.GetAttribute(stubs[5][0].Attributes, "db.response.status_code").AsString()
+		verifier.Assert(statusCode == "59", "Expect status code to be 59, got %s", statusCode)
+	}, 6)
+}
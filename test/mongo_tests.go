@@ -34,7 +34,8 @@ func init() {
 		NewMuzzleTestCase("mongo-1.11.1-crud-muzzle", mongo_dependency_name, mongo_module_name, "v1.11.1", "v1.15.1", "1.18", "", []string{"go", "build", "test_crud_mongo.go", "dsn.go"}),
 		NewMuzzleTestCase("mongo-1.11.1-cursor-muzzle", mongo_dependency_name, mongo_module_name, "v1.11.1", "v1.15.1", "1.18", "", []string{"go", "build", "test_batch.go", "dsn.go"}),
 		NewMuzzleTestCase("mongo-1.11.1-batch-muzzle", mongo_dependency_name, mongo_module_name, "v1.11.1", "v1.15.1", "1.18", "", []string{"go", "build", "test_cursor.go", "dsn.go"}),
-		NewLatestDepthTestCase("mongo-1.11.1-latestDepth", mongo_dependency_name, mongo_module_name, "v1.11.1", "v1.15.1", "1.18", "", TestCrudMongo))
+		NewLatestDepthTestCase("mongo-1.11.1-latestDepth", mongo_dependency_name, mongo_module_name, "v1.11.1", "v1.15.1", "1.18", "", TestCrudMongo),
+		NewGeneralTestCase("mongo-2.0.0-crud-test", mongo_module_name, "v2.0.0", "", "1.18", "", TestCrudMongoV2))
 }
 
 func TestCrudMongo(t *testing.T, env ...string) {
@@ -45,6 +46,14 @@ func TestCrudMongo(t *testing.T, env ...string) {
 	RunApp(t, "test_crud_mongo", env...)
 }
 
+func TestCrudMongoV2(t *testing.T, env ...string) {
+	_, mongoPort := initMongoContainer()
+	UseApp("mongo/v2.0.0")
+	RunGoBuild(t, "go", "build", "test_crud_mongo.go", "dsn.go")
+	env = append(env, "MONGO_PORT="+mongoPort.Port())
+	RunApp(t, "test_crud_mongo", env...)
+}
+
 func TestCursor(t *testing.T, env ...string) {
 	_, mongoPort := initMongoContainer()
 	UseApp("mongo/v1.11.1")
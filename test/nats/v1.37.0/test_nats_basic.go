@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Run an in-process NATS server with JetStream
+	storeDir, err := os.MkdirTemp("", "nats")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(storeDir)
+	natsServer, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  storeDir,
+	})
+	if err != nil {
+		panic(err)
+	}
+	go natsServer.Start()
+	defer natsServer.Shutdown()
+	if !natsServer.ReadyForConnections(10 * time.Second) {
+		panic("nats server is not ready")
+	}
+
+	nc, err := nats.Connect(natsServer.ClientURL())
+	if err != nil {
+		panic(err)
+	}
+	defer nc.Close()
+
+	// Publish to a subscription handler
+	received := make(chan struct{}, 1)
+	if _, err = nc.Subscribe("greet", func(msg *nats.Msg) {
+		received <- struct{}{}
+	}); err != nil {
+		panic(err)
+	}
+	if err = nc.Publish("greet", []byte("hello world")); err != nil {
+		panic(err)
+	}
+	<-received
+
+	// Request and reply through the inbox of the requester
+	if _, err = nc.Subscribe("service", func(msg *nats.Msg) {
+		if err := msg.Respond([]byte("pong")); err != nil {
+			panic(err)
+		}
+	}); err != nil {
+		panic(err)
+	}
+	if _, err = nc.Request("service", []byte("ping"), 5*time.Second); err != nil {
+		panic(err)
+	}
+
+	// Fetch one message from a stream and consume another
+	js, err := jetstream.New(nc)
+	if err != nil {
+		panic(err)
+	}
+	if _, err = js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     "ORDERS",
+		Subjects: []string{"orders.*"},
+	}); err != nil {
+		panic(err)
+	}
+	consumer, err := js.CreateOrUpdateConsumer(ctx, "ORDERS", jetstream.ConsumerConfig{
+		Durable:   "worker",
+		AckPolicy: jetstream.AckExplicitPolicy,
+	})
+	if err != nil {
+		panic(err)
+	}
+	if _, err = js.Publish(ctx, "orders.fetched", []byte("order1")); err != nil {
+		panic(err)
+	}
+	// The headers of the user are kept next to the injected trace context
+	if _, err = js.PublishMsgAsync(&nats.Msg{
+		Subject: "orders.async",
+		Header:  nats.Header{"Order-Id": []string{"3"}},
+		Data:    []byte("order3"),
+	}); err != nil {
+		panic(err)
+	}
+	select {
+	case <-js.PublishAsyncComplete():
+	case <-time.After(5 * time.Second):
+		panic("async publish is not acked")
+	}
+	_, fetchSpan := otel.Tracer("").Start(ctx, "fetch orders")
+	batch, err := consumer.Fetch(1, jetstream.FetchMaxWait(5*time.Second))
+	if err != nil {
+		panic(err)
+	}
+	for msg := range batch.Messages() {
+		if err = msg.Ack(); err != nil {
+			panic(err)
+		}
+	}
+	if err = batch.Error(); err != nil {
+		panic(err)
+	}
+	msg, err := consumer.Next(jetstream.FetchMaxWait(5 * time.Second))
+	if err != nil {
+		panic(err)
+	}
+	verifier.Assert(msg.Headers().Get("Order-Id") == "3", "Expect the header of the user, got %v", msg.Headers())
+	verifier.Assert(msg.Headers().Get("traceparent") != "", "Expect the trace context in the headers, got %v", msg.Headers())
+	if err = msg.Ack(); err != nil {
+		panic(err)
+	}
+	fetchSpan.End()
+	if _, err = js.Publish(ctx, "orders.consumed", []byte("order2")); err != nil {
+		panic(err)
+	}
+	consumed := make(chan struct{}, 1)
+	consumeContext, err := consumer.Consume(func(msg jetstream.Msg) {
+		if err := msg.Ack(); err != nil {
+			panic(err)
+		}
+		consumed <- struct{}{}
+	})
+	if err != nil {
+		panic(err)
+	}
+	<-consumed
+	consumeContext.Stop()
+
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		publishes := map[string]trace.SpanID{}
+		var receiveSpans tracetest.SpanStubs
+		for _, stub := range stubs {
+			switch stub[0].Name {
+			case "greet publish":
+				verifier.Assert(len(stub) == 2, "Expect publish and process spans, got %d spans", len(stub))
+				verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", "greet", "nats")
+				verifier.VerifyMQConsumeAttributes(stub[1], "", "", "", "process", "greet", "nats")
+				verifier.Assert(stub[1].Parent.SpanID() == stub[0].SpanContext.SpanID(),
+					"Expect process span to be a child of the publish span")
+			case "service publish":
+				// The reply is published to the inbox of the requester
+				verifier.Assert(len(stub) == 3, "Expect request, process and reply spans, got %d spans", len(stub))
+				verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", "service", "nats")
+				verifier.VerifyMQConsumeAttributes(stub[1], "", "", "", "process", "service", "nats")
+				verifier.VerifyMQPublishAttributes(stub[2], "", "", "", "publish", "(temporary)", "nats")
+				verifier.Assert(verifier.GetAttribute(stub[2].Attributes, "messaging.destination.temporary").AsBool(),
+					"Expect the reply subject to be a temporary destination")
+				verifier.Assert(stub[1].Parent.SpanID() == stub[0].SpanContext.SpanID(),
+					"Expect process span to be a child of the request span")
+				verifier.Assert(stub[2].Parent.SpanID() == stub[1].SpanContext.SpanID(),
+					"Expect reply span to be a child of the process span")
+			case "orders.fetched publish", "orders.async publish":
+				subject := strings.TrimSuffix(stub[0].Name, " publish")
+				verifier.Assert(len(stub) == 1, "Expect a single publish span, got %d spans", len(stub))
+				verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", subject, "nats")
+				publishes[subject] = stub[0].SpanContext.SpanID()
+			case "fetch orders":
+				// The receive spans belong to the trace of the fetcher
+				verifier.Assert(len(stub) == 3, "Expect fetch and two receive spans, got %d spans", len(stub))
+				for _, span := range stub[1:] {
+					verifier.Assert(span.Parent.SpanID() == stub[0].SpanContext.SpanID(),
+						"Expect receive span to be a child of the fetch span")
+					verifier.Assert(span.EndTime.After(span.StartTime), "Expect receive span to cover the wait for the message")
+				}
+				receiveSpans = stub[1:]
+			case "orders.consumed publish":
+				verifier.Assert(len(stub) == 2, "Expect publish and process spans, got %d spans", len(stub))
+				verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", "orders.consumed", "nats")
+				verifier.VerifyMQConsumeAttributes(stub[1], "", "", "", "process", "orders.consumed", "nats")
+				verifier.Assert(stub[1].Parent.SpanID() == stub[0].SpanContext.SpanID(),
+					"Expect process span to be a child of the publish span")
+			default:
+				verifier.Assert(false, "Unexpected trace starting with %s", stub[0].Name)
+			}
+		}
+		verifier.Assert(len(receiveSpans) == 2, "Expect receive spans for the fetched messages")
+		for _, span := range receiveSpans {
+			subject := strings.TrimSuffix(span.Name, " receive")
+			verifier.VerifyMQConsumeAttributes(span, "", "", "", "receive", subject, "nats")
+			verifier.Assert(len(span.Links) == 1 && span.Links[0].SpanContext.SpanID() == publishes[subject],
+				"Expect receive span to link to the publish span, got %v", span.Links)
+		}
+	}, 6)
+}
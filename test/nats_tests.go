@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const natsModuleName = "nats"
+
+const natsDependencyName = "github.com/nats-io/nats.go"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("nats-1.37.0-basic-test", natsModuleName, "v1.37.0", "v1.37.0", "1.21.0", "", TestNatsBasic),
+		// Conn.subscribe gained the errCh parameter in v1.38.0
+		NewGeneralTestCase("nats-1.38.0-basic-test", natsModuleName, "v1.38.0", "v1.38.0", "1.21.0", "", TestNatsV138Basic),
+		// Conn.publish gained the validateReply parameter in v1.48.0
+		NewGeneralTestCase("nats-1.48.0-basic-test", natsModuleName, "v1.48.0", "", "1.23.0", "", TestNatsV148Basic),
+		NewMuzzleTestCase("nats-1.37.0-muzzle", natsDependencyName, natsModuleName, "v1.37.0", "", "1.23.0", "", []string{"go", "build", "test_nats_basic.go"}),
+		NewLatestDepthTestCase("nats-1.37.0-basic-latestDepth", natsDependencyName, natsModuleName, "v1.37.0", "", "1.23.0", "", TestNatsBasic),
+	)
+}
+
+func TestNatsBasic(t *testing.T, env ...string) {
+	UseApp("nats/v1.37.0")
+	RunGoBuild(t, "go", "build", "test_nats_basic.go")
+	RunApp(t, "test_nats_basic", env...)
+}
+
+func TestNatsV138Basic(t *testing.T, env ...string) {
+	useNatsVersion(t, "v1.38.0")
+	TestNatsBasic(t, env...)
+}
+
+func TestNatsV148Basic(t *testing.T, env ...string) {
+	useNatsVersion(t, "v1.48.0")
+	TestNatsBasic(t, env...)
+}
+
+// useNatsVersion fetches version into the nats app, whose code is the same for
+// every version, and restores its go.mod once the test is done
+func useNatsVersion(t *testing.T, version string) {
+	UseApp("nats/v1.37.0")
+	goModPath, err := filepath.Abs("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	goMod, err := os.ReadFile(goModPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.WriteFile(goModPath, goMod, 0644); err != nil {
+			t.Error(err)
+		}
+	})
+	FetchVersion(t, natsDependencyName, version)
+}
@@ -22,6 +22,9 @@ func init() {
 		NewGeneralTestCase("nethttp-http-2-test", "nethttp", "", "", "1.18", "", TestHttp2),
 		NewGeneralTestCase("nethttp-https-test", "nethttp", "", "", "1.18", "", TestHttps),
 		NewGeneralTestCase("nethttp-metric-test", "nethttp", "", "", "1.18", "", TestHttpMetric),
+		NewGeneralTestCase("nethttp-client-trace-events-test", "nethttp", "", "", "1.18", "", TestHttpClientTraceEvents),
+		NewGeneralTestCase("nethttp-client-trace-spans-test", "nethttp", "", "", "1.18", "", TestHttpClientTraceSpans),
+		NewGeneralTestCase("nethttp-filter-test", "nethttp", "", "", "1.18", "", TestHttpFilter),
 	)
 }
 
@@ -48,3 +51,25 @@ func TestHttpMetric(t *testing.T, env ...string) {
 	RunGoBuild(t, "go", "build", "test_http_metrics.go", "http_server.go")
 	RunApp(t, "test_http_metrics", env...)
 }
+
+func TestHttpClientTraceEvents(t *testing.T, env ...string) {
+	UseApp("nethttp")
+	RunGoBuild(t, "go", "build", "test_http_client_trace.go", "http_server.go")
+	env = append(env, "OTEL_INSTRUMENTATION_NETHTTP_CLIENT_TRACE=events")
+	RunApp(t, "test_http_client_trace", env...)
+}
+
+func TestHttpClientTraceSpans(t *testing.T, env ...string) {
+	UseApp("nethttp")
+	RunGoBuild(t, "go", "build", "test_http_client_trace.go", "http_server.go")
+	env = append(env, "OTEL_INSTRUMENTATION_NETHTTP_CLIENT_TRACE=spans")
+	RunApp(t, "test_http_client_trace", env...)
+}
+
+func TestHttpFilter(t *testing.T, env ...string) {
+	UseApp("nethttp")
+	RunGoBuild(t, "go", "build", "test_http_filter.go", "http_server.go")
+	env = append(env, "OTEL_INSTRUMENTATION_HTTP_SERVER_EXCLUDE_PATHS=/healthz",
+		"OTEL_INSTRUMENTATION_HTTP_CLIENT_EXCLUDE_PATHS=/b")
+	RunApp(t, "test_http_filter", env...)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func get(client *http.Client, url string) {
+	resp, err := client.Get(url)
+	if err != nil {
+		panic(err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+}
+
+func findSpan(stubs tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for i := range stubs {
+		if stubs[i].Name == name {
+			return &stubs[i]
+		}
+	}
+	return nil
+}
+
+func findEvent(span tracetest.SpanStub, name string) *trace.Event {
+	for i := range span.Events {
+		if span.Events[i].Name == name {
+			return &span.Events[i]
+		}
+	}
+	return nil
+}
+
+func main() {
+	http.HandleFunc("/b", helloHandler)
+	var err error
+	port, err = verifier.GetFreePort()
+	if err != nil {
+		panic(err)
+	}
+	go func() {
+		if err := http.ListenAndServe("127.0.0.1:"+strconv.Itoa(port), nil); err != nil {
+			panic(err)
+		}
+	}()
+	ts := httptest.NewTLSServer(http.HandlerFunc(helloHandler))
+	defer ts.Close()
+	time.Sleep(1 * time.Second)
+
+	client := &http.Client{Transport: &http.Transport{}}
+	// the first request dials a new connection, the second one reuses it
+	get(client, "http://localhost:"+strconv.Itoa(port)+"/b")
+	get(client, "http://localhost:"+strconv.Itoa(port)+"/b")
+	get(ts.Client(), ts.URL+"/b")
+
+	mode := os.Getenv("OTEL_INSTRUMENTATION_NETHTTP_CLIENT_TRACE")
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for i := 0; i < 3; i++ {
+			client := stubs[i][0]
+			verifier.Assert(verifier.GetAttribute(client.Attributes, "network.peer.address").AsString() == "127.0.0.1",
+				"Expect peer address to be 127.0.0.1, got %s", verifier.GetAttribute(client.Attributes, "network.peer.address").AsString())
+			verifier.Assert(verifier.GetAttribute(client.Attributes, "network.peer.port").AsInt64() > 0, "Expect peer port to be set")
+			verifier.Assert(findEvent(client, "http.first_response_byte") != nil, "Expect first response byte event in trace %d", i)
+			acquired := findEvent(client, "http.conn.acquired")
+			verifier.Assert(acquired != nil, "Expect connection acquired event in trace %d", i)
+			reused := verifier.GetAttribute(acquired.Attributes, "http.conn.reused").AsBool()
+			verifier.Assert(reused == (i == 1), "Expect http.conn.reused of trace %d to be %v, got %v", i, i == 1, reused)
+		}
+		switch mode {
+		case "events":
+			for _, name := range []string{"http.dns.start", "http.dns.done", "http.connect.start", "http.connect.done"} {
+				verifier.Assert(findEvent(stubs[0][0], name) != nil, "Expect %s event", name)
+			}
+			verifier.Assert(findEvent(stubs[1][0], "http.connect.start") == nil, "Expect no connect event for the reused connection")
+			tlsDone := findEvent(stubs[2][0], "http.tls.done")
+			verifier.Assert(tlsDone != nil, "Expect http.tls.done event")
+			verifier.Assert(verifier.GetAttribute(tlsDone.Attributes, "tls.protocol.name").AsString() == "tls", "Expect tls.protocol.name to be tls")
+		case "spans":
+			for _, name := range []string{"http.dns", "http.connect"} {
+				span := findSpan(stubs[0], name)
+				verifier.Assert(span != nil, "Expect %s span", name)
+				verifier.Assert(span.Parent.SpanID() == stubs[0][0].SpanContext.SpanID(), "Expect %s span to be child of the client span", name)
+			}
+			connect := findSpan(stubs[0], "http.connect")
+			verifier.Assert(verifier.GetAttribute(connect.Attributes, "network.peer.port").AsInt64() == int64(port),
+				"Expect connect peer port to be %d", port)
+			verifier.Assert(findSpan(stubs[1], "http.connect") == nil, "Expect no connect span for the reused connection")
+			verifier.Assert(findSpan(stubs[2], "http.tls") != nil, "Expect http.tls span")
+		default:
+			log.Fatalf("unexpected mode %q", mode)
+		}
+	}, 3)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	// the span continues the trace of the caller without reading the request
+	_, span := otel.Tracer("handler").Start(context.Background(), "check")
+	span.End()
+	w.WriteHeader(http.StatusOK)
+}
+
+func setupFilteredHttp() {
+	http.HandleFunc("/healthz", healthHandler)
+	http.HandleFunc("/b", helloHandler)
+	var err error
+	port, err = verifier.GetFreePort()
+	if err != nil {
+		panic(err)
+	}
+	err = http.ListenAndServe(":"+strconv.Itoa(port), nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func get(path string) {
+	resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(port) + path)
+	if err != nil {
+		panic(err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+}
+
+func main() {
+	go setupFilteredHttp()
+	time.Sleep(1 * time.Second)
+	// /healthz is excluded on the server, /b on the client, both requests are
+	// served on the same connection
+	get("/healthz")
+	get("/b")
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.Assert(len(stubs[0]) == 2, "Expect 2 spans in the trace of /healthz, got %d", len(stubs[0]))
+		verifier.Assert(stubs[0][1].Name == "check" && stubs[0][1].Parent.SpanID() == stubs[0][0].SpanContext.SpanID(),
+			"Expect the handler span to be a child of the client span")
+		verifier.Assert(len(stubs[1]) == 1 && stubs[1][0].Name == "GET /b", "Expect only the server span of /b, got %d spans", len(stubs[1]))
+		verifier.Assert(!stubs[1][0].Parent.IsValid(), "Expect the server span of /b to start a new trace")
+	}, 2)
+}
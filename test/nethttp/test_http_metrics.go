@@ -64,5 +64,32 @@ func main() {
 			}
 			verifier.VerifyHttpClientMetricsAttributes(point.DataPoints[0].Attributes.ToSlice(), "GET", "127.0.0.1:"+strconv.Itoa(port), "", "http", "1.1", port, 200)
 		},
+		"http.server.response.body.size": func(mrs metricdata.ResourceMetrics) {
+			if len(mrs.ScopeMetrics) <= 0 {
+				panic("No http.server.response.body.size metrics received!")
+			}
+			point := mrs.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[int64])
+			if point.DataPoints[0].Sum != int64(len("success")) {
+				panic("http.server.response.body.size should be the size of the response, actually " + strconv.Itoa(int(point.DataPoints[0].Sum)))
+			}
+		},
+		"http.client.response.body.size": func(mrs metricdata.ResourceMetrics) {
+			if len(mrs.ScopeMetrics) <= 0 {
+				panic("No http.client.response.body.size metrics received!")
+			}
+			point := mrs.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[int64])
+			if point.DataPoints[0].Sum != int64(len("success")) {
+				panic("http.client.response.body.size should be the size of the response, actually " + strconv.Itoa(int(point.DataPoints[0].Sum)))
+			}
+		},
+		"http.server.active_requests": func(mrs metricdata.ResourceMetrics) {
+			if len(mrs.ScopeMetrics) <= 0 {
+				panic("No http.server.active_requests metrics received!")
+			}
+			point := mrs.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+			if point.DataPoints[0].Value != 0 {
+				panic("http.server.active_requests should be 0 after the request, actually " + strconv.Itoa(int(point.DataPoints[0].Value)))
+			}
+		},
 	})
 }
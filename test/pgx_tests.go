@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+)
+
+const pgx_dependency_name = "github.com/jackc/pgx/v5"
+const pgx_module_name = "pgx"
+
+func init() {
+	TestCases = append(TestCases, NewGeneralTestCase("test_pgx_crud", pgx_module_name, "v5.0.0", "", "1.19", "", TestPgxCrud),
+		NewGeneralTestCase("test_pgx_pool", pgx_module_name, "v5.0.0", "", "1.19", "", TestPgxPool),
+		NewLatestDepthTestCase("test_pgx_crud", pgx_dependency_name, pgx_module_name, "v5.0.0", "", "1.19", "", TestPgxCrud),
+		NewMuzzleTestCase("test_pgx_crud_muzzle", pgx_dependency_name, pgx_module_name, "v5.0.0", "", "1.19", "", []string{"go", "build", "test_pgx_crud.go"}))
+}
+
+func TestPgxCrud(t *testing.T, env ...string) {
+	_, postgresPort := initPostgresContainer()
+	UseApp("pgx/v5.0.0")
+	RunGoBuild(t, "go", "build", "test_pgx_crud.go")
+	env = append(env, "POSTGRES_PORT="+postgresPort.Port())
+	RunApp(t, "test_pgx_crud", env...)
+}
+
+func TestPgxPool(t *testing.T, env ...string) {
+	_, postgresPort := initPostgresContainer()
+	UseApp("pgx/v5.0.0")
+	RunGoBuild(t, "go", "build", "test_pgx_pool.go")
+	env = append(env, "POSTGRES_PORT="+postgresPort.Port())
+	RunApp(t, "test_pgx_pool", env...)
+}
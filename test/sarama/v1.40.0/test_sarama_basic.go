@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// headerCarrier reads the trace context from the headers of a message
+type headerCarrier struct {
+	msg *sarama.ConsumerMessage
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, header := range c.msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, header := range c.msg.Headers {
+		keys = append(keys, string(header.Key))
+	}
+	return keys
+}
+
+// markingHandler marks every consumed message and stops after count messages
+type markingHandler struct {
+	count    int
+	consumed int
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (h *markingHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *markingHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *markingHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		// The message carries the context of the producer
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier{msg})
+		_, span := otel.Tracer("handler").Start(ctx, "handle")
+		span.End()
+		session.MarkMessage(msg, "")
+		h.consumed++
+		if h.consumed == h.count {
+			h.once.Do(func() { close(h.done) })
+		}
+	}
+	return nil
+}
+
+func main() {
+	config := newConfig()
+	addrs := []string{getKafkaAddress()}
+
+	// Send one message with the sync producer
+	syncProducer, err := sarama.NewSyncProducer(addrs, config)
+	if err != nil {
+		panic(err)
+	}
+	if _, _, err = syncProducer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicName,
+		Value: sarama.StringEncoder("hello world1"),
+	}); err != nil {
+		panic(err)
+	}
+	if err = syncProducer.Close(); err != nil {
+		panic(err)
+	}
+
+	// Send one message with the async producer
+	asyncProducer, err := sarama.NewAsyncProducer(addrs, config)
+	if err != nil {
+		panic(err)
+	}
+	verifier.Assert(len(config.Producer.Interceptors) == 0, "Expect the config of the application to be left untouched")
+	asyncProducer.Input() <- &sarama.ProducerMessage{
+		Topic: topicName,
+		Value: sarama.StringEncoder("hello world2"),
+	}
+	select {
+	case <-asyncProducer.Successes():
+	case produceErr := <-asyncProducer.Errors():
+		panic(produceErr)
+	}
+	if err = asyncProducer.Close(); err != nil {
+		panic(err)
+	}
+
+	// Consume both messages with a consumer group
+	group, err := sarama.NewConsumerGroup(addrs, groupName, config)
+	if err != nil {
+		panic(err)
+	}
+	handler := &markingHandler{count: 2, done: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	consumed := make(chan error, 1)
+	go func() {
+		for ctx.Err() == nil {
+			if err := group.Consume(ctx, []string{topicName}, handler); err != nil {
+				consumed <- err
+				return
+			}
+		}
+		consumed <- nil
+	}()
+	<-handler.done
+	cancel()
+	if err = <-consumed; err != nil {
+		panic(err)
+	}
+	if err = group.Close(); err != nil {
+		panic(err)
+	}
+
+	// Every message is published and processed in the same trace, the
+	// handler and the process span are both children of the publish span
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		for _, stub := range stubs {
+			verifier.Assert(len(stub) == 3, "Expect 3 spans in the trace, got %d", len(stub))
+			verifier.VerifyMQPublishAttributes(stub[0], "", "", "", "publish", topicName, "kafka")
+			for _, child := range stub[1:] {
+				verifier.Assert(child.Parent.SpanID() == stub[0].SpanContext.SpanID(),
+					"Expect %s span to be a child of the publish span", child.Name)
+				if child.Name != "handle" {
+					verifier.VerifyMQConsumeAttributes(child, "", "", "", "process", topicName, "kafka")
+				}
+			}
+		}
+	}, 2)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+)
+
+const saramaModuleName = "sarama"
+
+const saramaDependencyName = "github.com/IBM/sarama"
+
+func init() {
+	TestCases = append(TestCases,
+		NewGeneralTestCase("sarama-basic-test", saramaModuleName, "1.40.0", "", "1.18.0", "", TestSaramaKafka),
+		NewMuzzleTestCase("sarama-muzzle-test", saramaDependencyName, saramaModuleName, "v1.40.0", "", "1.18.0", "", []string{"go", "build", "test_sarama_basic.go", "base.go"}),
+		NewLatestDepthTestCase("sarama-latestdepth-test", saramaDependencyName, saramaModuleName, "v1.40.0", "", "1.18.0", "", TestSaramaKafka),
+	)
+}
+
+func TestSaramaKafka(t *testing.T, env ...string) {
+	containers := initKafkaContainer(t)
+	defer containers.CleanupContainers(context.Background())
+	UseApp("sarama/v1.40.0")
+	RunGoBuild(t, "go", "build", "test_sarama_basic.go", "base.go")
+	env = append(env, "KAFKA_ADDR="+containers.KafkaAddress)
+	RunApp(t, "test_sarama_basic", env...)
+}
@@ -29,9 +29,8 @@ func main() {
 	producer := initProducer()
 	defer producer.Close()
 
-	// Initialize consumer with cleanup
+	// Initialize consumer
 	consumer := initConsumer()
-	defer consumer.Close()
 
 	// Send message
 	messages := []kafka.Message{kafka.Message{
@@ -52,11 +51,20 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// Closing the consumer ends the process span of the last message
+	if err = consumer.Close(); err != nil {
+		panic(err)
+	}
 
 	// Verify OpenTelemetry traces
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
 		verifier.VerifyMQPublishAttributes(stubs[0][0], "", "", "", "publish", topicName, "kafka")
 		verifier.VerifyMQConsumeAttributes(stubs[0][1], "", "", "", "process", topicName, "kafka")
 		verifier.VerifyMQConsumeAttributes(stubs[0][2], "", "", "", "process", topicName, "kafka")
-	}, 1)
+		for _, receive := range []tracetest.SpanStub{stubs[1][0], stubs[2][0]} {
+			verifier.VerifyMQConsumeAttributes(receive, "", "", "", "receive", topicName, "kafka")
+			verifier.Assert(len(receive.Links) == 1 && receive.Links[0].SpanContext.SpanID() == stubs[0][0].SpanContext.SpanID(),
+				"Expect receive span to link to the publish span, got %v", receive.Links)
+		}
+	}, 3)
 }
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/test/verifier"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Send one message with the writer and one with the low level client
+	producer := initProducer()
+	producer.AllowAutoTopicCreation = true
+	if err := producer.WriteMessages(ctx, kafka.Message{Value: []byte("hello writer")}); err != nil {
+		panic(err)
+	}
+	if err := producer.Close(); err != nil {
+		panic(err)
+	}
+	client := &kafka.Client{Addr: kafka.TCP(getKafkaAddress())}
+	resp, err := client.Produce(ctx, &kafka.ProduceRequest{
+		Topic:        topicName,
+		Partition:    0,
+		RequiredAcks: kafka.RequireAll,
+		Records:      kafka.NewRecordReader(kafka.Record{Value: kafka.NewBytes([]byte("hello client"))}),
+	})
+	if err != nil {
+		panic(err)
+	}
+	if resp.Error != nil {
+		panic(resp.Error)
+	}
+
+	// Fetch and commit the messages with a consumer group
+	consumer := initConsumer()
+	for i := 0; i < 2; i++ {
+		msg, err := consumer.FetchMessage(ctx)
+		if err != nil {
+			panic(err)
+		}
+		if err = consumer.CommitMessages(ctx, msg); err != nil {
+			panic(err)
+		}
+	}
+	if err = consumer.Close(); err != nil {
+		panic(err)
+	}
+
+	// Read the messages again with a batch from the partition leader
+	conn, err := kafka.DialLeader(ctx, "tcp", getKafkaAddress(), topicName, 0)
+	if err != nil {
+		panic(err)
+	}
+	if _, err = conn.Seek(0, kafka.SeekAbsolute); err != nil {
+		panic(err)
+	}
+	batch := conn.ReadBatch(1, 10e6)
+	for i := 0; i < 2; i++ {
+		if _, err = batch.ReadMessage(); err != nil {
+			panic(err)
+		}
+	}
+	if err = batch.Close(); err != nil {
+		panic(err)
+	}
+	if _, err = conn.Seek(0, kafka.SeekAbsolute); err != nil {
+		panic(err)
+	}
+	batch = conn.ReadBatchWith(kafka.ReadBatchConfig{MinBytes: 1, MaxBytes: 10e6})
+	for i := 0; i < 2; i++ {
+		if _, err = batch.ReadMessage(); err != nil {
+			panic(err)
+		}
+	}
+	if err = batch.Close(); err != nil {
+		panic(err)
+	}
+	if err = conn.Close(); err != nil {
+		panic(err)
+	}
+
+	// Verify OpenTelemetry traces
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		counts := make(map[string]int)
+		for _, stub := range stubs {
+			for _, span := range stub {
+				counts[span.Name]++
+				switch span.Name {
+				case topicName + " publish":
+					verifier.VerifyMQPublishAttributes(span, "", "", "", "publish", topicName, "kafka")
+				case topicName + " receive":
+					verifier.VerifyMQConsumeAttributes(span, "", "", "", "receive", topicName, "kafka")
+					verifier.Assert(len(span.Links) == 1, "Expect receive span to link to the publish span, got %v", span.Links)
+				case topicName + " process":
+					verifier.VerifyMQConsumeAttributes(span, "", "", "", "process", topicName, "kafka")
+					verifier.Assert(stub[0].Name == topicName+" publish" && span.Parent.SpanID() == stub[0].SpanContext.SpanID(),
+						"Expect process span to be a child of the publish span")
+				case topicName + " commit":
+					verifier.Assert(span.SpanKind == trace.SpanKindClient, "Expect commit span to be client span, got %d", span.SpanKind)
+					verifier.Assert(verifier.GetAttribute(span.Attributes, "messaging.operation.name").AsString() == "commit",
+						"Expect messaging.operation.name to be commit")
+				}
+			}
+		}
+		verifier.Assert(counts[topicName+" publish"] == 2, "Expect 2 publish spans, got %d", counts[topicName+" publish"])
+		verifier.Assert(counts[topicName+" receive"] == 2, "Expect 2 receive spans, got %d", counts[topicName+" receive"])
+		verifier.Assert(counts[topicName+" process"] == 6, "Expect 6 process spans, got %d", counts[topicName+" process"])
+		verifier.Assert(counts[topicName+" commit"] == 2, "Expect 2 commit spans, got %d", counts[topicName+" commit"])
+	}, 6)
+}
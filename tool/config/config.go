@@ -60,6 +60,12 @@ type BuildConfig struct {
 	// PkgPath specifies the path of the package to be used across multiple
 	// instrumentations
 	PkgPath string
+
+	// TryUntested true means dependencies whose versions fall outside the
+	// version range of every rule are instrumented with the nearest rule
+	// anyway. Hooks of such rules are only bound once their signatures are
+	// verified against the target function.
+	TryUntested bool
 }
 
 var conf *BuildConfig
@@ -248,6 +254,8 @@ func Configure() error {
 		"Disable specific rules. Use 'all' to disable all default rules, or comma-separated list of rule file names to disable specific rules")
 	flag.StringVar(&bc.PkgPath, "pkg", bc.PkgPath,
 		"Specify the path of the package to be used across multiple instrumentations")
+	flag.BoolVar(&bc.TryUntested, "try-untested", bc.TryUntested,
+		"Apply the nearest rule to dependencies whose versions are not covered by any rule")
 	err = flag.CommandLine.Parse(os.Args[2:])
 	if err != nil {
 		return ex.Error(err)
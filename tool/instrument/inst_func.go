@@ -396,6 +396,17 @@ func (rp *RuleProcessor) applyFuncRules(bundle *rules.RuleBundle) (err error) {
 					// Apply all matched rules for this function
 					fnRules := sortFuncRules(rules)
 					for _, rule := range fnRules {
+						// Rules applied out of their version ranges must
+						// still match the signature of the target function
+						if needsSignatureCheck(rule, rp.exact) {
+							err = verifyHookSignature(rule, fnDecl,
+								bundle.PackageName)
+							if err != nil {
+								util.Log("Skip untested rule %s: %v",
+									rule, err)
+								continue
+							}
+						}
 						if rule.UseRaw {
 							err = rp.insertRaw(rule, fnDecl)
 						} else {
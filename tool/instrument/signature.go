@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/ast"
+	"github.com/alibaba/loongsuite-go-agent/tool/ex"
+	"github.com/alibaba/loongsuite-go-agent/tool/rules"
+	"github.com/dave/dst"
+)
+
+// -----------------------------------------------------------------------------
+// Hook Signature Verification
+//
+// Rules applied out of their version ranges in -try-untested mode are bound to
+// target functions that may have changed since the rules were written. Binding
+// a hook to a function of a different signature either fails the compilation
+// of the instrumented package or, worse, panics the tool, so the signatures are
+// verified beforehand and the rule is skipped if they no longer match.
+
+// needsSignatureCheck reports whether the hooks of the rule must be verified
+// before they are bound to a target function. Rules applied within their
+// version ranges are tested against the target, so only untested rules are
+// verified. Raw rules have no hook function at all, and rules matching the
+// target by a regexp bind hooks that are not required to mirror the signature
+// of every matched function, so neither of them can be verified.
+func needsSignatureCheck(rule *rules.InstFuncRule, exact bool) bool {
+	return rule.Untested && !rule.UseRaw && exact
+}
+
+// verifyHookSignature checks that the parameters of the hooks of the rule
+// still match the receiver, parameters and results of the target function.
+// A hook parameter of type interface{} matches any type, as hooks declare
+// unexported types of the target package that way.
+func verifyHookSignature(rule *rules.InstFuncRule, target *dst.FuncDecl,
+	pkgName string) error {
+	file, err := findHookFile(rule)
+	if err != nil {
+		return err
+	}
+	root, err := ast.ParseAstFromFile(file)
+	if err != nil {
+		return err
+	}
+	// The hook file refers to the target package by either its package name or
+	// an explicit alias, both of them are deemed as the target package
+	qualifiers := map[string]bool{pkgName: true}
+	if spec := ast.FindImport(root, rule.ImportPath); spec != nil &&
+		spec.Name != nil {
+		qualifiers[spec.Name.Name] = true
+	}
+	hookTypes := func(hook *dst.FuncDecl) []string {
+		// The first parameter is always the CallContext
+		return fieldTypes(hook.Type.Params, 1, func(t dst.Expr) string {
+			return hookTypeString(t, qualifiers)
+		})
+	}
+	targetTypes := func(fields ...*dst.FieldList) []string {
+		var res []string
+		for _, field := range fields {
+			res = append(res, fieldTypes(field, 0, targetTypeString)...)
+		}
+		return res
+	}
+	if rule.OnEnter != "" {
+		hook := ast.FindFuncDecl(root, rule.OnEnter)
+		if hook == nil {
+			return ex.Errorf(nil, "hook %s not found", rule.OnEnter)
+		}
+		err = compareTypes(rule.OnEnter, hookTypes(hook),
+			targetTypes(target.Recv, target.Type.Params))
+		if err != nil {
+			return err
+		}
+	}
+	if rule.OnExit != "" {
+		hook := ast.FindFuncDecl(root, rule.OnExit)
+		if hook == nil {
+			return ex.Errorf(nil, "hook %s not found", rule.OnExit)
+		}
+		err = compareTypes(rule.OnExit, hookTypes(hook),
+			targetTypes(target.Type.Results))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// anyType is what hookTypeString renders interface{} as, it matches any type
+const anyType = "interface{}"
+
+func compareTypes(hook string, hookTypes, targetTypes []string) error {
+	if len(hookTypes) != len(targetTypes) {
+		return ex.Errorf(nil, "hook %s expects %d parameters, "+
+			"but target has %d (%s)", hook, len(hookTypes), len(targetTypes),
+			strings.Join(targetTypes, ", "))
+	}
+	for i, expect := range hookTypes {
+		actual := targetTypes[i]
+		// The wildcard still requires both of them to be variadic or not
+		variadic := strings.HasPrefix(actual, "...")
+		if expect == anyType && !variadic ||
+			expect == "..."+anyType && variadic {
+			continue
+		}
+		if expect != actual {
+			return ex.Errorf(nil, "hook %s expects %s at parameter %d, "+
+				"but target has %s", hook, expect, i+1, actual)
+		}
+	}
+	return nil
+}
+
+// fieldTypes renders the type of every parameter of the field list, skipping
+// the first skip parameters. Parameters declared together as "a, b T" are
+// rendered separately.
+func fieldTypes(fields *dst.FieldList, skip int,
+	render func(dst.Expr) string) []string {
+	if fields == nil {
+		return nil
+	}
+	var res []string
+	for _, field := range fields.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			res = append(res, render(field.Type))
+		}
+	}
+	if len(res) < skip {
+		return nil
+	}
+	return res[skip:]
+}
+
+// targetTypeString renders a type of the target package, types declared by
+// the target package itself are left unqualified
+func targetTypeString(t dst.Expr) string {
+	return typeString(t, func(ident *dst.Ident) string {
+		return ident.Name
+	}, func(sel *dst.SelectorExpr) string {
+		return typeString(sel.X, nil, nil) + "." + sel.Sel.Name
+	})
+}
+
+// hookTypeString renders a type of the hook file, types qualified with the
+// target package are left unqualified to match targetTypeString
+func hookTypeString(t dst.Expr, qualifiers map[string]bool) string {
+	return typeString(t, func(ident *dst.Ident) string {
+		if ident.Name == "any" {
+			return anyType
+		}
+		return ident.Name
+	}, func(sel *dst.SelectorExpr) string {
+		if x, ok := sel.X.(*dst.Ident); ok && qualifiers[x.Name] {
+			return sel.Sel.Name
+		}
+		return typeString(sel.X, nil, nil) + "." + sel.Sel.Name
+	})
+}
+
+func typeString(t dst.Expr, ident func(*dst.Ident) string,
+	selector func(*dst.SelectorExpr) string) string {
+	str := func(t dst.Expr) string {
+		return typeString(t, ident, selector)
+	}
+	switch t := t.(type) {
+	case *dst.Ident:
+		if ident != nil {
+			return ident(t)
+		}
+		return t.Name
+	case *dst.SelectorExpr:
+		if selector != nil {
+			return selector(t)
+		}
+		return str(t.X) + "." + t.Sel.Name
+	case *dst.StarExpr:
+		return "*" + str(t.X)
+	case *dst.ParenExpr:
+		return str(t.X)
+	case *dst.Ellipsis:
+		return "..." + str(t.Elt)
+	case *dst.ArrayType:
+		if t.Len == nil {
+			return "[]" + str(t.Elt)
+		}
+		if lit, ok := t.Len.(*dst.BasicLit); ok {
+			return "[" + lit.Value + "]" + str(t.Elt)
+		}
+		return "[?]" + str(t.Elt)
+	case *dst.MapType:
+		return "map[" + str(t.Key) + "]" + str(t.Value)
+	case *dst.ChanType:
+		switch t.Dir {
+		case dst.SEND:
+			return "chan<- " + str(t.Value)
+		case dst.RECV:
+			return "<-chan " + str(t.Value)
+		}
+		return "chan " + str(t.Value)
+	case *dst.FuncType:
+		params := fieldTypes(t.Params, 0, str)
+		results := fieldTypes(t.Results, 0, str)
+		return fmt.Sprintf("func(%s) (%s)", strings.Join(params, ", "),
+			strings.Join(results, ", "))
+	case *dst.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return anyType
+		}
+		return "interface{...}"
+	case *dst.StructType:
+		fields := make([]string, 0)
+		if t.Fields != nil {
+			for _, field := range t.Fields.List {
+				fields = append(fields, str(field.Type))
+			}
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	case *dst.IndexExpr:
+		return str(t.X) + "[" + str(t.Index) + "]"
+	case *dst.IndexListExpr:
+		indices := make([]string, 0, len(t.Indices))
+		for _, index := range t.Indices {
+			indices = append(indices, str(index))
+		}
+		return str(t.X) + "[" + strings.Join(indices, ", ") + "]"
+	}
+	return fmt.Sprintf("%T", t)
+}
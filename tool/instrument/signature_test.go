@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package instrument
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/ast"
+	"github.com/alibaba/loongsuite-go-agent/tool/rules"
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+const signatureTarget = `package target
+
+import "context"
+
+type Client struct{}
+
+type List[T any] []T
+
+func (c *Client) Do(ctx context.Context, args ...string) (int, error) { return 0, nil }
+
+func Sum(list List[int], pairs map[string][2]int) int { return 0 }
+`
+
+const signatureHooks = `package hook
+
+import (
+	"context"
+
+	"github.com/alibaba/loongsuite-go-agent/pkg/api"
+	alias "example.com/target"
+)
+
+func doOnEnter(call api.CallContext, c *alias.Client, ctx context.Context, args ...string) {}
+
+func doOnExit(call api.CallContext, n int, err error) {}
+
+func doAnyOnEnter(call api.CallContext, c interface{}, ctx any, args ...interface{}) {}
+
+func doSliceOnEnter(call api.CallContext, c *alias.Client, ctx context.Context, args []string) {}
+
+func doFewerOnEnter(call api.CallContext, c *alias.Client, ctx context.Context) {}
+
+func doInt64OnExit(call api.CallContext, n int64, err error) {}
+
+func sumOnEnter(call api.CallContext, list alias.List[int], pairs map[string][2]int) {}
+
+func sumStringOnEnter(call api.CallContext, list alias.List[string], pairs map[string][2]int) {}
+`
+
+func parseTestFile(t *testing.T, src string) *dst.File {
+	t.Helper()
+	root, err := decorator.Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestNeedsSignatureCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		untested bool
+		useRaw   bool
+		exact    bool
+		want     bool
+	}{
+		{"tested rule", false, false, true, false},
+		{"untested rule", true, false, true, true},
+		{"untested raw rule", true, true, true, false},
+		{"untested regexp rule", true, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &rules.InstFuncRule{
+				InstBaseRule: rules.InstBaseRule{Untested: tt.untested},
+				UseRaw:       tt.useRaw,
+			}
+			if got := needsSignatureCheck(rule, tt.exact); got != tt.want {
+				t.Errorf("needsSignatureCheck() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyHookSignature(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "hook.go"), []byte(signatureHooks), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := parseTestFile(t, signatureTarget)
+	tests := []struct {
+		name     string
+		function string
+		onEnter  string
+		onExit   string
+		wantErr  string
+	}{
+		{"matching hooks", "Do", "doOnEnter", "doOnExit", ""},
+		{"interface{} matches any type", "Do", "doAnyOnEnter", "", ""},
+		{"slice does not match variadic", "Do", "doSliceOnEnter", "", "expects []string at parameter 3"},
+		{"missing parameter", "Do", "doFewerOnEnter", "", "expects 2 parameters"},
+		{"mismatched result", "Do", "", "doInt64OnExit", "expects int64 at parameter 1"},
+		{"generic parameter", "Sum", "sumOnEnter", "", ""},
+		{"mismatched type argument", "Sum", "sumStringOnEnter", "", "expects List[string]"},
+		{"hook not found", "Do", "doOnEnter", "notExist", "no hook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &rules.InstFuncRule{
+				InstBaseRule: rules.InstBaseRule{
+					ImportPath: "example.com/target",
+					Path:       dir,
+				},
+				Function: tt.function,
+				OnEnter:  tt.onEnter,
+				OnExit:   tt.onExit,
+			}
+			fn := ast.FindFuncDecl(target, tt.function)
+			err := verifyHookSignature(rule, fn, "target")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verifyHookSignature() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifyHookSignature() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompareTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    []string
+		target  []string
+		wantErr bool
+	}{
+		{"equal", []string{"int", "*Client"}, []string{"int", "*Client"}, false},
+		{"no parameters", nil, nil, false},
+		{"different count", []string{"int"}, []string{"int", "error"}, true},
+		{"different type", []string{"int64"}, []string{"int"}, true},
+		{"wildcard", []string{"interface{}"}, []string{"map[string]int"}, false},
+		{"wildcard does not match variadic", []string{"interface{}"}, []string{"...int"}, true},
+		{"variadic wildcard", []string{"...interface{}"}, []string{"...int"}, false},
+		{"variadic wildcard does not match slice", []string{"...interface{}"}, []string{"[]int"}, true},
+		{"variadic", []string{"...string"}, []string{"...string"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compareTypes("hook", tt.hook, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compareTypes() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	tests := []struct {
+		typ        string
+		wantTarget string
+		wantHook   string
+	}{
+		{"int", "int", "int"},
+		{"any", "any", "interface{}"},
+		{"interface{}", "interface{}", "interface{}"},
+		{"interface{ Close() error }", "interface{...}", "interface{...}"},
+		{"*Client", "*Client", "*Client"},
+		{"*target.Client", "*target.Client", "*Client"},
+		{"context.Context", "context.Context", "context.Context"},
+		{"[]byte", "[]byte", "[]byte"},
+		{"[4]byte", "[4]byte", "[4]byte"},
+		{"map[string][]int", "map[string][]int", "map[string][]int"},
+		{"chan<- int", "chan<- int", "chan<- int"},
+		{"<-chan int", "<-chan int", "<-chan int"},
+		{"chan int", "chan int", "chan int"},
+		{"func(a, b int) error", "func(int, int) (error)", "func(int, int) (error)"},
+		{"struct{ a int; b string }", "struct{int; string}", "struct{int; string}"},
+		{"List[int]", "List[int]", "List[int]"},
+		{"target.Pair[string, *target.Client]", "target.Pair[string, *target.Client]", "Pair[string, *Client]"},
+	}
+	qualifiers := map[string]bool{"target": true}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			root := parseTestFile(t, "package p\n\nvar v "+tt.typ+"\n")
+			typ := root.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Type
+			if got := targetTypeString(typ); got != tt.wantTarget {
+				t.Errorf("targetTypeString() = %q, want %q", got, tt.wantTarget)
+			}
+			if got := hookTypeString(typ, qualifiers); got != tt.wantHook {
+				t.Errorf("hookTypeString() = %q, want %q", got, tt.wantHook)
+			}
+		})
+	}
+}
+
+func TestFieldTypes(t *testing.T) {
+	root := parseTestFile(t, "package p\n\nfunc f(ctx context.Context, a, b int, rest ...string) {}\n")
+	params := root.Decls[0].(*dst.FuncDecl).Type.Params
+	got := fieldTypes(params, 1, targetTypeString)
+	want := []string{"int", "int", "...string"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fieldTypes() = %v, want %v", got, want)
+	}
+	if got := fieldTypes(params, 5, targetTypeString); got != nil {
+		t.Errorf("fieldTypes() = %v, want nil", got)
+	}
+}
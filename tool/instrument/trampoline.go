@@ -571,7 +571,8 @@ func (rp *RuleProcessor) implementCallContext(t *rules.InstFuncRule) {
 
 func setValue(field string, idx int, typ dst.Expr) *dst.CaseClause {
 	// *(c.Params[idx].(*int)) = val.(int)
-	// c.Params[idx] = val iff type is interface{}
+	// *(c.Params[idx].(*interface{})) = val iff type is interface{}, the slot
+	// holds the address of the parameter like for any other type
 	se := ast.SelectorExpr(ast.Ident(TrampolineCtxIdentifier), field)
 	ie := ast.IndexExpr(se, ast.IntLit(idx))
 	te := ast.TypeAssertExpr(ie, ast.DereferenceOf(typ))
@@ -580,7 +581,7 @@ func setValue(field string, idx int, typ dst.Expr) *dst.CaseClause {
 	val := ast.Ident(TrampolineValIdentifier)
 	assign := ast.AssignStmt(de, ast.TypeAssertExpr(val, typ))
 	if ast.IsInterfaceType(typ) {
-		assign = ast.AssignStmt(ie, val)
+		assign = ast.AssignStmt(de, val)
 	}
 	caseClause := ast.SwitchCase(
 		ast.Exprs(ast.IntLit(idx)),
@@ -591,16 +592,12 @@ func setValue(field string, idx int, typ dst.Expr) *dst.CaseClause {
 
 func getValue(field string, idx int, typ dst.Expr) *dst.CaseClause {
 	// return *(c.Params[idx].(*int))
-	// return c.Params[idx] iff type is interface{}
 	se := ast.SelectorExpr(ast.Ident(TrampolineCtxIdentifier), field)
 	ie := ast.IndexExpr(se, ast.IntLit(idx))
 	te := ast.TypeAssertExpr(ie, ast.DereferenceOf(typ))
 	pe := ast.ParenExpr(te)
 	de := ast.DereferenceOf(pe)
 	ret := ast.ReturnStmt(ast.Exprs(de))
-	if ast.IsInterfaceType(typ) {
-		ret = ast.ReturnStmt(ast.Exprs(ie))
-	}
 	caseClause := ast.SwitchCase(
 		ast.Exprs(ast.IntLit(idx)),
 		ast.Stmts(ret),
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/ast"
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+func printCaseClause(t *testing.T, clause *dst.CaseClause) string {
+	t.Helper()
+	file := &dst.File{
+		Name: ast.Ident("p"),
+		Decls: []dst.Decl{&dst.FuncDecl{
+			Name: ast.Ident("f"),
+			Type: &dst.FuncType{Params: &dst.FieldList{}},
+			Body: ast.Block(&dst.SwitchStmt{Body: ast.Block(clause)}),
+		}},
+	}
+	var buf bytes.Buffer
+	if err := decorator.Fprint(&buf, file); err != nil {
+		t.Fatal(err)
+	}
+	// The printer breaks interface{} into lines, compare without blanks
+	return strings.Join(strings.Fields(buf.String()), "")
+}
+
+func TestParamClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		clause func() *dst.CaseClause
+		want   string
+	}{
+		{
+			name:   "set int",
+			clause: func() *dst.CaseClause { return setParamClause(1, ast.Ident("int")) },
+			want:   "*(c.Params[1].(*int)) = val.(int)",
+		},
+		{
+			name:   "set interface{} through the address",
+			clause: func() *dst.CaseClause { return setParamClause(2, ast.InterfaceType()) },
+			want:   "*(c.Params[2].(*interface{})) = val",
+		},
+		{
+			name:   "get int",
+			clause: func() *dst.CaseClause { return getParamClause(1, ast.Ident("int")) },
+			want:   "return *(c.Params[1].(*int))",
+		},
+		{
+			name:   "get interface{} through the address",
+			clause: func() *dst.CaseClause { return getParamClause(2, ast.InterfaceType()) },
+			want:   "return *(c.Params[2].(*interface{}))",
+		},
+		{
+			name:   "set return value of interface{}",
+			clause: func() *dst.CaseClause { return setReturnValClause(0, ast.InterfaceType()) },
+			want:   "*(c.ReturnVals[0].(*interface{})) = val",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := printCaseClause(t, tt.clause())
+			want := strings.Join(strings.Fields(tt.want), "")
+			if !strings.Contains(got, want) {
+				t.Errorf("want %q in\n%s", tt.want, got)
+			}
+		})
+	}
+}
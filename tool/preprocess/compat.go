@@ -0,0 +1,292 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/config"
+	"github.com/alibaba/loongsuite-go-agent/tool/rules"
+	"github.com/alibaba/loongsuite-go-agent/tool/util"
+	"golang.org/x/mod/semver"
+)
+
+// -----------------------------------------------------------------------------
+// Compatibility Report
+//
+// Rules are only applied to the versions they are tested against. Once a
+// library is upgraded beyond the version range of its rules, matchVersion
+// drops them and the telemetry of the library silently disappears. The
+// compatibility report lists every library of the build that rules are known
+// for, its resolved version and whether the rules still cover it.
+
+// compatEntry is the coverage of one package by its rules
+type compatEntry struct {
+	importPath string
+	version    string
+	// hooks is the number of distinct functions, structs and files the rules
+	// of the package instrument
+	hooks int
+	// missing is the number of hooks none of whose rules covers the resolved
+	// version, and uncovered holds the version ranges of these rules
+	missing   int
+	uncovered []string
+	// untested is the number of missing hooks that are applied with their
+	// nearest rule in -try-untested mode
+	untested int
+}
+
+func (e *compatEntry) status() string {
+	switch {
+	case e.missing == 0:
+		return "covered"
+	case e.untested == e.missing:
+		return fmt.Sprintf("untested, %d of %d hooks out of %s applied anyway",
+			e.untested, e.hooks, strings.Join(e.uncovered, " "))
+	case e.missing == e.hooks:
+		return fmt.Sprintf("not covered, supported %s",
+			strings.Join(e.uncovered, " "))
+	default:
+		return fmt.Sprintf("partially covered, %d of %d hooks out of %s",
+			e.missing, e.hooks, strings.Join(e.uncovered, " "))
+	}
+}
+
+type compatReport struct {
+	lock    sync.Mutex
+	entries map[string]*compatEntry
+}
+
+func newCompatReport() *compatReport {
+	return &compatReport{entries: make(map[string]*compatEntry)}
+}
+
+func (r *compatReport) add(entry *compatEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries[entry.importPath] = entry
+}
+
+// hookKey identifies what a rule instruments. Rules of the same hook differ in
+// the version ranges they apply to, and usually in their hook functions too,
+// as the signature of the target function changes between the ranges
+func hookKey(rule rules.InstRule) string {
+	switch rl := rule.(type) {
+	case *rules.InstFuncRule:
+		return "func:" + rl.Function + "," + rl.ReceiverType
+	case *rules.InstStructRule:
+		return "struct:" + rl.StructType + "," + rl.FieldName
+	case *rules.InstFileRule:
+		return "file:" + rl.FileName
+	}
+	return rule.String()
+}
+
+// nearestRule finds the rule whose version range is closest to the version,
+// preferring the latest range below the version over the earliest range above
+// it, as libraries are far more often upgraded than downgraded
+func nearestRule(version string, candidates []rules.InstRule) rules.InstRule {
+	var below, above rules.InstRule
+	var belowEnd, aboveStart string
+	for _, rule := range candidates {
+		if rule.GetVersion() == "" {
+			continue
+		}
+		start, end := splitVersionRange(strings.ReplaceAll(rule.GetVersion(), " ", ""))
+		if end != "v" && semver.Compare(version, end) >= 0 {
+			if below == nil || semver.Compare(end, belowEnd) > 0 {
+				below, belowEnd = rule, end
+			}
+		} else if start != "v" && semver.Compare(version, start) < 0 {
+			if above == nil || semver.Compare(start, aboveStart) < 0 {
+				above, aboveStart = rule, start
+			}
+		}
+	}
+	if below != nil {
+		return below
+	}
+	return above
+}
+
+// isSuperseded checks if the hook is replaced by another hook of the same rule
+// package in the version, e.g. rules of a function that was deprecated in favor
+// of a new one end exactly where the rules of the new function start. Such
+// hooks are not reported as uncovered.
+func isSuperseded(version string, hookRules, candidates []rules.InstRule) bool {
+	key := hookKey(hookRules[0])
+	minStart, maxEnd := "", ""
+	for _, rule := range hookRules {
+		start, end := splitVersionRange(strings.ReplaceAll(rule.GetVersion(), " ", ""))
+		if minStart == "" || semver.Compare(start, minStart) < 0 {
+			minStart = start
+		}
+		// An unbounded end is kept as "v"
+		if end == "v" || maxEnd == "v" {
+			maxEnd = "v"
+		} else if maxEnd == "" || semver.Compare(end, maxEnd) > 0 {
+			maxEnd = end
+		}
+	}
+	for _, rule := range candidates {
+		if rule.GetVersion() == "" || hookKey(rule) == key ||
+			rule.GetPath() != hookRules[0].GetPath() {
+			continue
+		}
+		matched, err := matchVersion(version, rule.GetVersion())
+		if err != nil || !matched {
+			continue
+		}
+		start, end := splitVersionRange(strings.ReplaceAll(rule.GetVersion(), " ", ""))
+		if maxEnd != "v" && semver.Compare(start, maxEnd) >= 0 {
+			return true
+		}
+		if end != "v" && semver.Compare(end, minStart) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// asUntested copies the rule and marks it as applied out of its version range
+func asUntested(rule rules.InstRule) rules.InstRule {
+	switch rl := rule.(type) {
+	case *rules.InstFuncRule:
+		r := *rl
+		r.Untested = true
+		return &r
+	case *rules.InstStructRule:
+		r := *rl
+		r.Untested = true
+		return &r
+	}
+	return nil
+}
+
+// checkCompatibility records how the rules of the package cover its version.
+// With tryUntested, every hook none of whose rules covers the version is
+// applied with its nearest rule, which is returned along with the candidates.
+// File rules are never applied untested, since adding files to a package is
+// impossible to verify.
+func checkCompatibility(importPath, version string,
+	candidates []rules.InstRule, tryUntested bool) (*compatEntry, []rules.InstRule) {
+	all := candidates
+	hooks := make(map[string][]rules.InstRule)
+	keys := make([]string, 0)
+	for _, rule := range candidates {
+		key := hookKey(rule)
+		if _, exist := hooks[key]; !exist {
+			keys = append(keys, key)
+		}
+		hooks[key] = append(hooks[key], rule)
+	}
+	entry := &compatEntry{
+		importPath: importPath,
+		version:    version,
+		hooks:      len(hooks),
+	}
+	for _, key := range keys {
+		covered := false
+		ranges := make([]string, 0)
+		for _, rule := range hooks[key] {
+			matched, err := matchVersion(version, rule.GetVersion())
+			if err != nil {
+				// Malformed versions are reported by the rule matching
+				covered = true
+				break
+			}
+			if matched {
+				covered = true
+				break
+			}
+			ranges = append(ranges, rule.GetVersion())
+		}
+		if covered || isSuperseded(version, hooks[key], all) {
+			continue
+		}
+		entry.missing++
+		for _, r := range ranges {
+			if !containsString(entry.uncovered, r) {
+				entry.uncovered = append(entry.uncovered, r)
+			}
+		}
+		if !tryUntested {
+			continue
+		}
+		nearest := nearestRule(version, hooks[key])
+		if nearest == nil {
+			continue
+		}
+		if untested := asUntested(nearest); untested != nil {
+			candidates = append(candidates, untested)
+			entry.untested++
+		}
+	}
+	return entry, candidates
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// print writes the report to the debug log. It is printed to the console as
+// well if any library is not fully covered, or in verbose mode.
+func (r *compatReport) print() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.entries) == 0 {
+		return
+	}
+	entries := make([]*compatEntry, 0, len(r.entries))
+	allCovered := true
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+		if entry.missing > 0 {
+			allCovered = false
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].importPath < entries[j].importPath
+	})
+	for _, entry := range entries {
+		util.Log("Compatibility: %s %s %s", entry.importPath, entry.version,
+			entry.status())
+	}
+	if allCovered && !config.GetConf().Verbose {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "Instrumentation compatibility report:")
+	_, _ = fmt.Fprintln(w, "  LIBRARY\tVERSION\tSTATUS")
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", entry.importPath,
+			entry.version, entry.status())
+	}
+	if !allCovered && !config.GetConf().TryUntested {
+		_, _ = fmt.Fprintln(w, "Uncovered hooks are not instrumented, "+
+			"run `otel set -try-untested` to apply their nearest rules anyway.")
+	}
+	_ = w.Flush()
+}
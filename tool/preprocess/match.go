@@ -38,6 +38,7 @@ type ruleMatcher struct {
 	availableRules map[string][]rules.InstRule
 	moduleVersions []*vendorModule // vendor used only
 	projectDeps    map[string]bool // actual dependencies from dry run commands
+	compatReport   *compatReport   // coverage of the matched packages
 }
 
 func newRuleMatcher(compileCmds []string) *ruleMatcher {
@@ -55,6 +56,7 @@ func newRuleMatcher(compileCmds []string) *ruleMatcher {
 	return &ruleMatcher{
 		availableRules: availableRules,
 		projectDeps:    projectDeps,
+		compatReport:   newCompatReport(),
 	}
 }
 
@@ -371,6 +373,16 @@ func (rm *ruleMatcher) match(cmdArgs []string) *rules.RuleBundle {
 	util.Assert(goVersion != "", "sanity check")
 	util.Assert(strings.HasPrefix(goVersion, "go"), "sanity check")
 	goVersion = strings.Replace(goVersion, "go", "v", 1)
+
+	// Record whether the rules cover the resolved version of the package, and
+	// bring in the nearest rules of uncovered hooks in -try-untested mode.
+	// Standard library packages have no module version and are not reported.
+	if version := rm.findModuleVersion(importPath, cmdArgs); version != "" {
+		var entry *compatEntry
+		entry, filteredAvailables = checkCompatibility(importPath, version,
+			filteredAvailables, config.GetConf().TryUntested)
+		rm.compatReport.add(entry)
+	}
 	for _, candidate := range cmdArgs {
 		// It's not a go file, ignore silently
 		if !util.IsGoFile(candidate) {
@@ -392,14 +404,15 @@ func (rm *ruleMatcher) match(cmdArgs []string) *rules.RuleBundle {
 		for i := len(filteredAvailables) - 1; i >= 0; i-- {
 			rule := filteredAvailables[i]
 
-			// Check if the version is supported
+			// Check if the version is supported, untested rules are applied
+			// regardless of their version ranges
 			matched, err := matchVersion(version, rule.GetVersion())
 			if err != nil {
 				util.Log("Bad match: file %s, rule %s, version %s",
 					file, rule, version)
 				continue
 			}
-			if !matched {
+			if !matched && !rule.IsUntested() {
 				continue
 			}
 			// Check if the rule requires a specific Go version(range)
@@ -493,6 +506,26 @@ func (rm *ruleMatcher) match(cmdArgs []string) *rules.RuleBundle {
 	return bundle
 }
 
+// findModuleVersion finds the module version of the package being compiled,
+// either from vendor/modules.txt or from the path of its source files
+func (rm *ruleMatcher) findModuleVersion(importPath string, cmdArgs []string) string {
+	if rm.moduleVersions != nil {
+		recorded := findVendorModuleVersion(rm.moduleVersions, importPath)
+		if recorded != "" {
+			return recorded
+		}
+	}
+	for _, candidate := range cmdArgs {
+		if !util.IsGoFile(candidate) {
+			continue
+		}
+		if version := extractVersion(candidate); version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
 func findFlagValue(cmd []string, flag string) string {
 	for i, v := range cmd {
 		if v == flag {
@@ -645,6 +678,7 @@ func (dp *DepProcessor) matchRules() ([]*rules.RuleBundle, error) {
 	}
 
 	matcher := newRuleMatcher(compileCmds)
+	dp.compatReport = matcher.compatReport
 
 	// If we are in vendor mode, we need to parse the vendor/modules.txt file
 	// to get the version of each module for future matching
@@ -54,6 +54,7 @@ type DepProcessor struct {
 	vendorMode    bool
 	pkgModDir     string // Local module cache path of alibaba-otel pkg module
 	otelRuntimeGo string // Path to the otel.runtime.go file
+	compatReport  *compatReport
 }
 
 func newDepProcessor() *DepProcessor {
@@ -308,6 +309,11 @@ func Preprocess() error {
 			}
 		}
 
+		// Report the libraries whose versions are not covered by their rules
+		if dp.compatReport != nil {
+			dp.compatReport.print()
+		}
+
 		// Rectify file rules to make sure we can find them locally
 		err = dp.updateRule(bundles)
 		if err != nil {
@@ -14,7 +14,11 @@
 
 package preprocess
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/alibaba/loongsuite-go-agent/tool/rules"
+)
 
 func TestMatchVersion(t *testing.T) {
 	type args struct {
@@ -167,3 +171,229 @@ func TestMatchVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestNearestRule(t *testing.T) {
+	ruleOf := func(version string) rules.InstRule {
+		return &rules.InstFuncRule{
+			InstBaseRule: rules.InstBaseRule{Version: version},
+			Function:     "Foo",
+		}
+	}
+	candidates := []rules.InstRule{
+		ruleOf("[1.0.0,1.2.0)"),
+		ruleOf("[1.2.0,1.4.0)"),
+		ruleOf("[1.6.0,1.8.0)"),
+	}
+	tests := []struct {
+		name       string
+		version    string
+		candidates []rules.InstRule
+		want       string
+	}{
+		{
+			name:       "version is above all ranges",
+			version:    "v1.9.0",
+			candidates: candidates,
+			want:       "[1.6.0,1.8.0)",
+		},
+		{
+			name:       "version is between ranges",
+			version:    "v1.5.0",
+			candidates: candidates,
+			want:       "[1.2.0,1.4.0)",
+		},
+		{
+			name:       "version is below all ranges",
+			version:    "v0.9.0",
+			candidates: candidates,
+			want:       "[1.0.0,1.2.0)",
+		},
+		{
+			name:       "version equals range end",
+			version:    "v1.8.0",
+			candidates: candidates,
+			want:       "[1.6.0,1.8.0)",
+		},
+		{
+			name:       "rule version is not set",
+			version:    "v1.9.0",
+			candidates: []rules.InstRule{ruleOf("")},
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearestRule(tt.version, tt.candidates)
+			gotVersion := ""
+			if got != nil {
+				gotVersion = got.GetVersion()
+			}
+			if gotVersion != tt.want {
+				t.Errorf("nearestRule() = %v, want %v", gotVersion, tt.want)
+			}
+		})
+	}
+}
+
+func funcRuleOf(function, version, path string) rules.InstRule {
+	return &rules.InstFuncRule{
+		InstBaseRule: rules.InstBaseRule{Version: version, Path: path},
+		Function:     function,
+	}
+}
+
+func TestIsSuperseded(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		hookRules  []rules.InstRule
+		candidates []rules.InstRule
+		want       bool
+	}{
+		{
+			name:       "replaced by a later hook",
+			version:    "v1.6.0",
+			hookRules:  []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.5.0)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("New", "[1.5.0,)", "p")},
+			want:       true,
+		},
+		{
+			name:       "replacing an earlier hook",
+			version:    "v1.2.0",
+			hookRules:  []rules.InstRule{funcRuleOf("New", "[1.5.0,)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.5.0)", "p")},
+			want:       true,
+		},
+		{
+			name:    "all ranges of the hook are considered",
+			version: "v1.9.0",
+			hookRules: []rules.InstRule{
+				funcRuleOf("Old", "[1.0.0,1.3.0)", "p"),
+				funcRuleOf("Old", "[1.3.0,1.8.0)", "p"),
+			},
+			candidates: []rules.InstRule{funcRuleOf("New", "[1.8.0,)", "p")},
+			want:       true,
+		},
+		{
+			name:       "overlapping hooks are not replaced",
+			version:    "v1.9.0",
+			hookRules:  []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.8.0)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("New", "[1.5.0,)", "p")},
+			want:       false,
+		},
+		{
+			name:       "hooks of another rule package",
+			version:    "v1.6.0",
+			hookRules:  []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.5.0)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("New", "[1.5.0,)", "q")},
+			want:       false,
+		},
+		{
+			name:       "other ranges of the same hook",
+			version:    "v1.6.0",
+			hookRules:  []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.5.0)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("Old", "[1.5.0,1.6.0)", "p")},
+			want:       false,
+		},
+		{
+			name:       "replacement not covering the version",
+			version:    "v2.1.0",
+			hookRules:  []rules.InstRule{funcRuleOf("Old", "[1.0.0,1.5.0)", "p")},
+			candidates: []rules.InstRule{funcRuleOf("New", "[1.5.0,2.0.0)", "p")},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := append(append([]rules.InstRule{}, tt.hookRules...), tt.candidates...)
+			if got := isSuperseded(tt.version, tt.hookRules, candidates); got != tt.want {
+				t.Errorf("isSuperseded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	fileRule := &rules.InstFileRule{
+		InstBaseRule: rules.InstBaseRule{Version: "[1.0.0,1.5.0)", Path: "p"},
+		FileName:     "extra.go",
+	}
+	candidates := []rules.InstRule{
+		funcRuleOf("Foo", "[1.0.0,1.5.0)", "p"),
+		funcRuleOf("Foo", "[1.5.0,2.0.0)", "p"),
+		funcRuleOf("Old", "[1.0.0,1.5.0)", "p"),
+		funcRuleOf("New", "[1.5.0,)", "p"),
+		fileRule,
+	}
+	tests := []struct {
+		name        string
+		version     string
+		tryUntested bool
+		wantStatus  string
+		wantAdded   []string
+	}{
+		{
+			name:       "covered",
+			version:    "v1.2.0",
+			wantStatus: "covered",
+		},
+		{
+			// Old is replaced by New, and the file by the later ranges
+			name:       "superseded hooks are covered",
+			version:    "v1.6.0",
+			wantStatus: "covered",
+		},
+		{
+			name:       "partially covered",
+			version:    "v2.1.0",
+			wantStatus: "partially covered, 1 of 4 hooks out of [1.0.0,1.5.0) [1.5.0,2.0.0)",
+		},
+		{
+			name:       "not covered",
+			version:    "v0.9.0",
+			wantStatus: "not covered, supported [1.0.0,1.5.0) [1.5.0,2.0.0) [1.5.0,)",
+		},
+		{
+			name:        "nearest rule applied untested",
+			version:     "v2.1.0",
+			tryUntested: true,
+			wantStatus:  "untested, 1 of 4 hooks out of [1.0.0,1.5.0) [1.5.0,2.0.0) applied anyway",
+			wantAdded:   []string{"Foo [1.5.0,2.0.0)"},
+		},
+		{
+			// File rules are never applied untested
+			name:        "nearest rules applied untested except files",
+			version:     "v0.9.0",
+			tryUntested: true,
+			wantStatus:  "not covered, supported [1.0.0,1.5.0) [1.5.0,2.0.0) [1.5.0,)",
+			wantAdded:   []string{"Foo [1.0.0,1.5.0)", "Old [1.0.0,1.5.0)", "New [1.5.0,)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, got := checkCompatibility("example.com/lib", tt.version,
+				append([]rules.InstRule{}, candidates...), tt.tryUntested)
+			if status := entry.status(); status != tt.wantStatus {
+				t.Errorf("status() = %q, want %q", status, tt.wantStatus)
+			}
+			added := got[len(candidates):]
+			if len(added) != len(tt.wantAdded) {
+				t.Fatalf("checkCompatibility() added %d rules, want %d", len(added), len(tt.wantAdded))
+			}
+			for i, rule := range added {
+				fn := rule.(*rules.InstFuncRule)
+				if desc := fn.Function + " " + fn.Version; desc != tt.wantAdded[i] {
+					t.Errorf("added rule %d = %s, want %s", i, desc, tt.wantAdded[i])
+				}
+				if !fn.Untested {
+					t.Errorf("added rule %s is not marked untested", fn.Function)
+				}
+			}
+			for _, rule := range candidates {
+				if rule.IsUntested() {
+					t.Errorf("candidate %s is marked untested", rule)
+				}
+			}
+		})
+	}
+}
@@ -43,6 +43,7 @@ type InstRule interface {
 	GetImportPath() string // GetImportPath returns import path of the rule
 	GetPath() string       // GetPath returns the local path of the rule
 	SetPath(path string)   // SetPath sets the local path of the rule
+	IsUntested() bool      // IsUntested returns if the rule is out of range
 	String() string        // String returns string representation of rule
 	Verify() error         // Verify checks the rule is valid
 }
@@ -59,6 +60,9 @@ type InstBaseRule struct {
 	// Import path of the rule, e.g. "github.com/gin-gonic/gin", it designates
 	// the import path of rule, all other import path will not be instrumented
 	ImportPath string `json:"ImportPath,omitempty"`
+	// Untested is set when the rule is applied to a version outside of its
+	// version range, which happens only in -try-untested mode
+	Untested bool `json:"Untested,omitempty"`
 }
 
 func (rule *InstBaseRule) GetVersion() string {
@@ -81,6 +85,10 @@ func (rule *InstBaseRule) SetPath(path string) {
 	rule.Path = path
 }
 
+func (rule *InstBaseRule) IsUntested() bool {
+	return rule.Untested
+}
+
 // InstFuncRule finds specific function call and instrument by adding new code
 type InstFuncRule struct {
 	InstBaseRule